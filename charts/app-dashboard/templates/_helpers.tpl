@@ -60,3 +60,30 @@ Create the name of the service account to use
 {{- default "default" .Values.serviceAccount.name }}
 {{- end }}
 {{- end }}
+
+{{/*
+Rules to read the objects in the destination namespaces needed by the optional features.
+*/}}
+{{- define "app-dashboard.destinationRules" -}}
+{{- if .Values.podStatus }}
+- apiGroups: [""]
+  resources: ["pods"]
+  verbs: ["list"]
+{{- end }}
+{{- if .Values.events.enabled }}
+- apiGroups: [""]
+  resources: ["events"]
+  verbs: ["list"]
+{{- end }}
+{{- if .Values.discoverUrls }}
+- apiGroups: ["networking.k8s.io"]
+  resources: ["ingresses"]
+  verbs: ["get"]
+- apiGroups: ["gateway.networking.k8s.io"]
+  resources: ["httproutes", "gateways"]
+  verbs: ["get"]
+- apiGroups: ["route.openshift.io"]
+  resources: ["routes"]
+  verbs: ["get"]
+{{- end }}
+{{- end }}
@@ -20,54 +20,126 @@
 package app
 
 import (
+	"context"
+	"log"
+	"strings"
 	"time"
 )
 
+// DefaultSyncInterval is how often the applications are read if the config doesn't set an interval.
+const DefaultSyncInterval = 5 * time.Minute
+
 type Dashboard struct {
-	config                   *ApplicationConfig
-	web                      Webserver
-	gateway                  ApplicationGateway
-	syncResult               *ApplicationsSyncResult
-	refreshIntervalInSeconds float64
+	config       *ApplicationConfig
+	web          Webserver
+	gateway      ApplicationGateway
+	enrichers    []ApplicationEnricher
+	syncResult   *ApplicationsSyncResult
+	syncInterval time.Duration
 }
 
-func NewDashboard(gateway ApplicationGateway, web Webserver, config *ApplicationConfig) *Dashboard {
+func NewDashboard(gateway ApplicationGateway, web Webserver, config *ApplicationConfig, enrichers ...ApplicationEnricher) *Dashboard {
+	syncInterval := config.SyncInterval
+	if syncInterval <= 0 {
+		syncInterval = DefaultSyncInterval
+	}
 	return &Dashboard{
-		refreshIntervalInSeconds: 5 * 60,
-		gateway:                  gateway,
-		web:                      web,
-		config:                   config,
+		syncInterval: syncInterval,
+		gateway:      gateway,
+		enrichers:    enrichers,
+		web:          web,
+		config:       config,
 		syncResult: &ApplicationsSyncResult{
-			Res:             Applications{},
-			LastSync:        time.Now(),
-			InitialSync:     false,
-			IgnoreNamespace: ignoredNamespacesAsMap(config.IgnoredNamespaces),
-			Environment:     config.EnvironmentName,
-			GitVersion:      "",
-			AppVersion:      1,
+			Res:         Applications{},
+			LastSync:    time.Now(),
+			InitialSync: false,
+			Filters:     config.Filters,
+			Environment: config.EnvironmentName,
+			About:       About{Build: config.Build, Settings: config.Settings},
 		},
 	}
 }
 
-func (d *Dashboard) Run() {
-	go d.web.Start(8080, d.syncResult)
-	go d.syncApplications()
+// Run serves the dashboard and keeps the applications in sync until the context is cancelled or the webserver
+// fails. It returns after the webserver has been shut down and the sync loop has stopped.
+func (d *Dashboard) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	syncStopped := make(chan struct{})
+	go func() {
+		defer close(syncStopped)
+		d.syncApplications(ctx)
+	}()
+
+	err := d.web.Start(ctx, d.config.Port, d.syncResult)
+
+	cancel()
+	<-syncStopped
+	return err
+}
+
+func (d *Dashboard) syncApplications(ctx context.Context) {
+	ticker := time.NewTicker(d.syncInterval)
+	defer ticker.Stop()
+
+	for {
+		d.sync(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
 }
 
-func (d *Dashboard) syncApplications() {
-	for true {
-		d.syncResult.Res = d.gateway.GetApplications()
-		d.syncResult.LastSync = time.Now()
-		d.syncResult.InitialSync = true
+func (d *Dashboard) sync(ctx context.Context) {
+	applications, err := d.gateway.GetApplications(ctx)
+	// also refreshed if the sync failed, since the versions help finding the cause
+	d.refreshAbout(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Printf("Could not sync applications: %v", err)
+
+			d.syncResult.Lock()
+			d.syncResult.LastSyncError = err.Error()
+			d.syncResult.Unlock()
+		}
+		return
+	}
 
-		time.Sleep(time.Duration(d.refreshIntervalInSeconds * float64(time.Second)))
+	for _, enricher := range d.enrichers {
+		if err := enricher.Enrich(ctx, &applications); err != nil && ctx.Err() == nil {
+			log.Printf("Could not enrich applications: %v", err)
+		}
 	}
+
+	d.syncResult.Lock()
+	defer d.syncResult.Unlock()
+
+	d.syncResult.Res = applications
+	d.syncResult.LastSync = time.Now()
+	d.syncResult.LastSyncError = ""
+	d.syncResult.InitialSync = true
 }
 
-func ignoredNamespacesAsMap(namespaces []string) map[string]bool {
-	result := make(map[string]bool, len(namespaces))
-	for _, namespace := range namespaces {
-		result[namespace] = true
+// refreshAbout reads the versions of the cluster and the GitOps tool, which change on upgrades.
+func (d *Dashboard) refreshAbout(ctx context.Context) {
+	info := d.gateway.About(ctx)
+	if ctx.Err() != nil {
+		return
 	}
-	return result
+
+	d.syncResult.Lock()
+	defer d.syncResult.Unlock()
+
+	d.syncResult.About.GatewayInfo = info
+	d.syncResult.About.UpdatedAt = time.Now()
+	d.syncResult.GitVersion = info.KubernetesVersion
+}
+
+// IsFloatingImageTag reports whether the image references a tag that moves with new builds, like :latest or :main.
+func IsFloatingImageTag(image string) bool {
+	return strings.Contains(image, ":latest") || strings.Contains(image, ":main")
 }
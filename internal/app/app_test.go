@@ -0,0 +1,195 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package app
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type fakeGateway struct{}
+
+func (gateway *fakeGateway) GetApplications(ctx context.Context) (Applications, error) {
+	return Applications{Items: []Item{{Metadata: Metadata{Name: "irs", Namespace: "argocd"}}}}, nil
+}
+
+func (gateway *fakeGateway) About(ctx context.Context) GatewayInfo {
+	return GatewayInfo{Source: "Kubernetes API", KubernetesVersion: "v1.28.2", Tool: "Argo CD", ToolVersion: "v2.8.4"}
+}
+
+type fakeWebserver struct {
+	err error
+}
+
+func (web *fakeWebserver) Start(ctx context.Context, port int, syncResult *ApplicationsSyncResult) error {
+	if web.err != nil {
+		return web.err
+	}
+	<-ctx.Done()
+	return nil
+}
+
+func TestShouldStopRunningWhenContextIsCancelled(t *testing.T) {
+	dashboard := NewDashboard(&fakeGateway{}, &fakeWebserver{}, &ApplicationConfig{})
+	ctx, cancel := context.WithCancel(context.Background())
+
+	result := make(chan error, 1)
+	go func() {
+		result <- dashboard.Run(ctx)
+	}()
+	waitForInitialSync(dashboard, t)
+	cancel()
+
+	select {
+	case err := <-result:
+		if err != nil {
+			t.Errorf("Expected no error after cancellation! Got: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Dashboard did not stop after the context was cancelled!")
+	}
+
+	if len(dashboard.syncResult.Res.Items) != 1 {
+		t.Errorf("Applications not synced! Got: %v", dashboard.syncResult.Res)
+	}
+}
+
+func TestShouldReturnWebserverError(t *testing.T) {
+	webserverError := errors.New("port already in use")
+	dashboard := NewDashboard(&fakeGateway{}, &fakeWebserver{err: webserverError}, &ApplicationConfig{})
+
+	err := dashboard.Run(context.Background())
+
+	if !errors.Is(err, webserverError) {
+		t.Errorf("Webserver error not returned! \nexpected: %v \nGot: %v", webserverError, err)
+	}
+}
+
+type fakeEnricher struct{}
+
+func (enricher *fakeEnricher) Enrich(ctx context.Context, applications *Applications) error {
+	applications.Items[0].Metadata.Generation = 42
+	return errors.New("partially enriched")
+}
+
+func TestShouldKeepEnrichedApplicationsDespiteEnricherErrors(t *testing.T) {
+	dashboard := NewDashboard(&fakeGateway{}, &fakeWebserver{}, &ApplicationConfig{}, &fakeEnricher{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		_ = dashboard.Run(ctx)
+	}()
+	waitForInitialSync(dashboard, t)
+
+	dashboard.syncResult.RLock()
+	defer dashboard.syncResult.RUnlock()
+	if generation := dashboard.syncResult.Res.Items[0].Metadata.Generation; generation != 42 {
+		t.Errorf("Enriched applications not kept! \nexpected: %d \nGot: %d", 42, generation)
+	}
+}
+
+func TestShouldRefreshAboutWithEverySync(t *testing.T) {
+	config := &ApplicationConfig{Build: BuildInfo{Version: "1.2.0"}, Settings: []Setting{{Name: "pod-status", Value: "true"}}}
+	dashboard := NewDashboard(&fakeGateway{}, &fakeWebserver{}, config)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		_ = dashboard.Run(ctx)
+	}()
+	waitForInitialSync(dashboard, t)
+
+	dashboard.syncResult.RLock()
+	defer dashboard.syncResult.RUnlock()
+	about := dashboard.syncResult.About
+	if about.KubernetesVersion != "v1.28.2" || about.ToolVersion != "v2.8.4" || about.UpdatedAt.IsZero() || dashboard.syncResult.GitVersion != "v1.28.2" {
+		t.Errorf("Versions not refreshed! \nexpected: %s \nGot: %v %s", "v1.28.2, Argo CD v2.8.4", about, dashboard.syncResult.GitVersion)
+	}
+	if about.Build.Version != "1.2.0" || len(about.Settings) != 1 {
+		t.Errorf("Build and settings not kept! \nexpected: %s \nGot: %v", "1.2.0 with pod-status", about)
+	}
+}
+
+type countingGateway struct {
+	fakeGateway
+	syncs atomic.Int32
+}
+
+func (gateway *countingGateway) GetApplications(ctx context.Context) (Applications, error) {
+	gateway.syncs.Add(1)
+	return gateway.fakeGateway.GetApplications(ctx)
+}
+
+func TestShouldSyncAtTheConfiguredInterval(t *testing.T) {
+	gateway := &countingGateway{}
+	dashboard := NewDashboard(gateway, &fakeWebserver{}, &ApplicationConfig{SyncInterval: 10 * time.Millisecond})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		_ = dashboard.Run(ctx)
+	}()
+
+	deadline := time.Now().Add(time.Second)
+	for gateway.syncs.Load() < 3 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if syncs := gateway.syncs.Load(); syncs < 3 {
+		t.Errorf("Applications not synced at the configured interval! \nexpected: %s \nGot: %d", "at least 3 syncs", syncs)
+	}
+	if interval := NewDashboard(gateway, &fakeWebserver{}, &ApplicationConfig{}).syncInterval; interval != DefaultSyncInterval {
+		t.Errorf("Default interval not used! \nexpected: %v \nGot: %v", DefaultSyncInterval, interval)
+	}
+}
+
+func waitForInitialSync(dashboard *Dashboard, t *testing.T) {
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		dashboard.syncResult.RLock()
+		initialSync := dashboard.syncResult.InitialSync
+		dashboard.syncResult.RUnlock()
+		if initialSync {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("Initial sync did not happen!")
+}
+
+func TestShouldReturnDeployedVersionOfTheCurrentSource(t *testing.T) {
+	item := Item{}
+	item.Spec.Source = Source{RepoUrl: "https://eclipse-tractusx.github.io/charts/dev", Chart: "irs-helm", TargetRevision: "6.*"}
+	item.Status.History = []History{
+		{Id: 2, Revision: "6.9.0", Source: item.Spec.Source},
+		{Id: 1, Revision: "6.8.0", Source: item.Spec.Source},
+	}
+	if version := item.DeployedVersion(); version != "6.9.0" {
+		t.Errorf("Latest deployed revision expected! \nexpected: %s \nGot: %s", "6.9.0", version)
+	}
+
+	item.Spec.Source.Chart = "item-relationship-service"
+	if version := item.DeployedVersion(); version != "6.*" {
+		t.Errorf("Target revision of the changed source expected! \nexpected: %s \nGot: %s", "6.*", version)
+	}
+}
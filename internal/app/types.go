@@ -20,99 +20,410 @@
 package app
 
 import (
+	"context"
+	"strings"
+	"sync"
 	"time"
 )
 
 type ApplicationGateway interface {
-	GetApplications() Applications
-	ToolInfoAsHtml() string
+	GetApplications(ctx context.Context) (Applications, error)
+	// About describes where the applications are read from, it is refreshed with every sync
+	About(ctx context.Context) GatewayInfo
+}
+
+// ApplicationEnricher adds information from sources other than the gateway to the synced applications.
+type ApplicationEnricher interface {
+	Enrich(ctx context.Context, applications *Applications) error
 }
 
 type Webserver interface {
-	Start(port int, syncResult *ApplicationsSyncResult)
+	// Start serves the dashboard until the context is cancelled and shuts down gracefully afterwards.
+	Start(ctx context.Context, port int, syncResult *ApplicationsSyncResult) error
 }
 
 type ApplicationConfig struct {
-	IgnoredNamespaces []string
-	EnvironmentName   string
+	// Filters describes which applications are shown
+	Filters         string
+	EnvironmentName string
+	Port            int
+	// SyncInterval is how often the applications are read, DefaultSyncInterval if unset
+	SyncInterval time.Duration
+	Build        BuildInfo
+	// Settings are the active configuration shown in the about section
+	Settings []Setting
+}
+
+// GatewayInfo describes the source of the applications and the versions of the cluster and the GitOps tool; versions
+// that could not be read are "unknown", versions not available through the gateway are empty.
+type GatewayInfo struct {
+	// Source is where the applications are read from, i.e. the Kubernetes API or the URL of the Argo CD server
+	Source            string `json:"source"`
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+	// Tool is the GitOps tool deploying the applications, Argo CD or Flux
+	Tool        string `json:"tool,omitempty"`
+	ToolVersion string `json:"toolVersion,omitempty"`
+}
+
+// BuildInfo identifies the build of the dashboard.
+type BuildInfo struct {
+	Version string `json:"version"`
+	Commit  string `json:"commit,omitempty"`
+	Date    string `json:"date,omitempty"`
+}
+
+// Setting is an option of the dashboard and its value, never a credential.
+type Setting struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
 }
 
+// About is served in the about section of the dashboard and as JSON.
+type About struct {
+	GatewayInfo
+	Build    BuildInfo `json:"build"`
+	Settings []Setting `json:"settings"`
+	// UpdatedAt is when the gateway info was last refreshed
+	UpdatedAt time.Time `json:"updatedAt,omitempty"`
+}
+
+// ApplicationsSyncResult is written by the sync loop and read by the webserver, so access has to hold the lock.
 type ApplicationsSyncResult struct {
-	Res             Applications
-	LastSync        time.Time
-	InitialSync     bool
-	IgnoreNamespace map[string]bool
-	Environment     string
-	GitVersion      string
-	AppVersion      int
+	sync.RWMutex
+	Res           Applications
+	LastSync      time.Time
+	LastSyncError string
+	InitialSync   bool
+	Filters       string
+	Environment   string
+	// GitVersion is the Kubernetes version of the cluster, kept for templates from before the about section
+	GitVersion string
+	About      About
 }
 
 type Applications struct {
-	ApiVersion string
-	Items      []item
-	Kind       string
+	ApiVersion string `json:"apiVersion,omitempty"`
+	Items      []Item `json:"items"`
+	Kind       string `json:"kind,omitempty"`
+}
+
+type Item struct {
+	ApiVersion string   `json:"apiVersion,omitempty"`
+	Kind       string   `json:"kind,omitempty"`
+	Metadata   Metadata `json:"metadata"`
+	Spec       Spec     `json:"spec"`
+	Status     Status   `json:"status"`
+	// Pods are the pods actually running for the application, if the gateway reads them
+	Pods *PodSummary `json:"pods,omitempty"`
+	// Events are recent Warning events of the application's objects, newest first, if the gateway reads them
+	Events []Event `json:"events,omitempty"`
+	// Probes are the latest results of probing the external URLs, by URL, if the URLs are probed
+	Probes map[string]Probe `json:"probes,omitempty"`
+	// Owner lists who is responsible for the application, read from the configured labels and annotations
+	Owner []OwnerField `json:"owner,omitempty"`
+}
+
+// OwnerField is a label or annotation describing the owner of an application, i.e. team=edc or slack-channel=#edc.
+type OwnerField struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// IsLink reports whether the value is a URL worth linking, i.e. a docs-url.
+func (field OwnerField) IsLink() bool {
+	return strings.HasPrefix(field.Value, "https://") || strings.HasPrefix(field.Value, "http://")
+}
+
+// HasProblems tells whether the application has error conditions or its last operation failed.
+func (item Item) HasProblems() bool {
+	for _, condition := range item.Status.Conditions {
+		if condition.IsError() {
+			return true
+		}
+	}
+	return item.Status.OperationState != nil && item.Status.OperationState.IsFailed()
+}
+
+// LatestDeployment returns the history entry with the highest id, which is the most recent deployment.
+func (item Item) LatestDeployment() (History, bool) {
+	var latest History
+	found := false
+	for _, entry := range item.Status.History {
+		if !found || entry.Id > latest.Id {
+			latest = entry
+			found = true
+		}
+	}
+	return latest, found
+}
+
+// DeployedVersion returns the revision of the latest deployment, since the target revision may be a range like 1.2.*
+// or a branch. If the source changed since, i.e. to another chart, the target revision of the new source is returned.
+func (item Item) DeployedVersion() string {
+	if latest, found := item.LatestDeployment(); found && latest.Source.RepoUrl == item.Spec.Source.RepoUrl &&
+		latest.Source.Chart == item.Spec.Source.Chart {
+		return latest.Version()
+	}
+	return item.Spec.Source.TargetRevision
+}
+
+type Metadata struct {
+	Generation  int               `json:"generation,omitempty"`
+	Name        string            `json:"name"`
+	Namespace   string            `json:"namespace"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type Spec struct {
+	Destination Destination `json:"destination"`
+	Project     string      `json:"project,omitempty"`
+	Source      Source      `json:"source"`
+}
+
+type Status struct {
+	Conditions     []Condition     `json:"conditions,omitempty"`
+	Health         Health          `json:"health"`
+	History        []History       `json:"history,omitempty"`
+	OperationState *OperationState `json:"operationState,omitempty"`
+	Resources      []Resource      `json:"resources,omitempty"`
+	Summary        Summary         `json:"summary"`
+	Sync           StatusSync      `json:"sync"`
+}
+
+// Resource is an object managed by the application.
+type Resource struct {
+	Group           string  `json:"group,omitempty"`
+	Version         string  `json:"version,omitempty"`
+	Kind            string  `json:"kind"`
+	Namespace       string  `json:"namespace,omitempty"`
+	Name            string  `json:"name"`
+	Status          string  `json:"status,omitempty"`
+	Health          *Health `json:"health,omitempty"`
+	RequiresPruning bool    `json:"requiresPruning,omitempty"`
+}
+
+// NeedsAttention tells whether the resource is out of sync, degraded or missing. Resources without health, like
+// ConfigMaps, only need to be in sync.
+func (resource Resource) NeedsAttention() bool {
+	if resource.Status == "OutOfSync" {
+		return true
+	}
+	return resource.Health != nil && (resource.Health.Status == "Degraded" || resource.Health.Status == "Missing")
 }
 
-type item struct {
-	ApiVersion      string
-	Kind            string
-	Metadata        metadata
-	Spec            spec
-	Status          status
-	IgnoreNamespace bool
+// PodSummary describes the pods of an application as running in the cluster.
+type PodSummary struct {
+	Total      int         `json:"total"`
+	Ready      int         `json:"ready"`
+	Restarts   int         `json:"restarts"`
+	Containers []Container `json:"containers,omitempty"`
 }
 
-type metadata struct {
-	Generation int
-	Name       string
-	Namespace  string
+// Problems lists the containers waiting for a reason like CrashLoopBackOff or ImagePullBackOff.
+func (summary PodSummary) Problems() []Container {
+	var problems []Container
+	for _, container := range summary.Containers {
+		if container.IsProblem() {
+			problems = append(problems, container)
+		}
+	}
+	return problems
 }
 
-type spec struct {
-	Destination destination
-	Project     string
-	Source      Source
+// UnlistedImages are images running in the cluster, but missing in the images reported by Argo CD.
+func (summary PodSummary) UnlistedImages() []string {
+	var images []string
+	seen := map[string]bool{}
+	for _, container := range summary.Containers {
+		if !container.Listed && !seen[container.Image] {
+			seen[container.Image] = true
+			images = append(images, container.Image)
+		}
+	}
+	return images
 }
 
-type status struct {
-	Health  health
-	History []History
-	Summary summary
-	Sync    statusSync
+type Container struct {
+	Pod  string `json:"pod"`
+	Name string `json:"name"`
+	// Image as specified in the pod, ImageId contains the digest actually running
+	Image        string `json:"image"`
+	ImageId      string `json:"imageID,omitempty"`
+	Ready        bool   `json:"ready"`
+	RestartCount int    `json:"restartCount"`
+	// State is Running, Terminated or Waiting, Reason i.e. CrashLoopBackOff or OOMKilled
+	State  string `json:"state"`
+	Reason string `json:"reason,omitempty"`
+	// Listed tells whether the image is one of the images reported by Argo CD
+	Listed bool `json:"listed"`
 }
 
-type destination struct {
-	Namespace string
-	Server    string
+// IsProblem tells whether the container can't be started.
+func (container Container) IsProblem() bool {
+	switch container.Reason {
+	case "CrashLoopBackOff", "ImagePullBackOff", "ErrImagePull", "CreateContainerConfigError", "CreateContainerError",
+		"InvalidImageName", "RunContainerError":
+		return container.State == "Waiting"
+	}
+	return false
+}
+
+// Digest returns the digest of the running image, i.e. sha256:4c3d...
+func (container Container) Digest() string {
+	if _, digest, found := strings.Cut(container.ImageId, "@"); found {
+		return digest
+	}
+	return ""
+}
+
+// Event is a Kubernetes event concerning an object of the application.
+type Event struct {
+	Type    string `json:"type"`
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+	// Object is the involved object as <kind>/<name>
+	Object   string    `json:"object"`
+	Count    int       `json:"count"`
+	LastSeen time.Time `json:"lastSeen"`
+}
+
+// ProbeOf returns the latest probe of the external URL, nil if it wasn't probed yet.
+func (item Item) ProbeOf(url string) *Probe {
+	if probe, found := item.Probes[url]; found {
+		return &probe
+	}
+	return nil
+}
+
+// Probe is the result of requesting an external URL.
+type Probe struct {
+	// StatusCode is 0 if no response was received
+	StatusCode int           `json:"statusCode,omitempty"`
+	Latency    time.Duration `json:"latency"`
+	Error      string        `json:"error,omitempty"`
+	// CertificateExpiry is when the certificate presented for https URLs expires
+	CertificateExpiry *time.Time `json:"certificateExpiry,omitempty"`
+	CheckedAt         time.Time  `json:"checkedAt"`
+}
+
+// IsReachable reports whether the URL answered without a server error. Redirects and authentication challenges
+// count as reachable, since many applications redirect to a login.
+func (probe Probe) IsReachable() bool {
+	return probe.Error == "" && probe.StatusCode > 0 && probe.StatusCode < 500
+}
+
+// Condition reports a problem of the application, i.e. a ComparisonError or a SharedResourceWarning.
+type Condition struct {
+	Type               string `json:"type"`
+	Message            string `json:"message,omitempty"`
+	LastTransitionTime string `json:"lastTransitionTime,omitempty"`
+}
+
+// IsError tells error conditions apart from warnings, Argo CD names them accordingly.
+func (condition Condition) IsError() bool {
+	return strings.HasSuffix(condition.Type, "Error")
+}
+
+// OperationState is the state of the last sync operation.
+type OperationState struct {
+	Phase      string      `json:"phase,omitempty"`
+	Message    string      `json:"message,omitempty"`
+	StartedAt  string      `json:"startedAt,omitempty"`
+	FinishedAt string      `json:"finishedAt,omitempty"`
+	SyncResult *SyncResult `json:"syncResult,omitempty"`
+}
+
+// IsFailed tells whether the operation ended with a failure or an error.
+func (state OperationState) IsFailed() bool {
+	return state.Phase == "Failed" || state.Phase == "Error"
+}
+
+type SyncResult struct {
+	Revision  string           `json:"revision,omitempty"`
+	Resources []ResourceResult `json:"resources,omitempty"`
+}
+
+// ResourceResult is the outcome of syncing a single resource.
+type ResourceResult struct {
+	Group     string `json:"group,omitempty"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Status    string `json:"status,omitempty"`
+	Message   string `json:"message,omitempty"`
+	HookPhase string `json:"hookPhase,omitempty"`
+}
+
+// IsFailed tells whether the resource could not be synced or its hook failed.
+func (result ResourceResult) IsFailed() bool {
+	return result.Status == "SyncFailed" || result.HookPhase == "Failed" || result.HookPhase == "Error"
+}
+
+type Destination struct {
+	Namespace string `json:"namespace,omitempty"`
+	Server    string `json:"server,omitempty"`
 }
 
 type Source struct {
-	RepoUrl        string
-	Path           string
-	TargetRevision string
+	RepoUrl        string `json:"repoURL,omitempty"`
+	Path           string `json:"path,omitempty"`
+	TargetRevision string `json:"targetRevision,omitempty"`
+	// Chart is set instead of Path for charts installed from a Helm repository
+	Chart string `json:"chart,omitempty"`
 }
 
-type health struct {
-	Status string
+type Health struct {
+	Status  string `json:"status,omitempty"`
+	Message string `json:"message,omitempty"`
 }
 
 type History struct {
-	DeployStartedAt string
-	DeployedAt      string
-	Id              int
-	Revision        string
-	Source          Source
+	DeployStartedAt string `json:"deployStartedAt,omitempty"`
+	DeployedAt      string `json:"deployedAt,omitempty"`
+	Id              int    `json:"id"`
+	Revision        string `json:"revision,omitempty"`
+	Source          Source `json:"source"`
+	// Commits between the revision of the previous deployment and this one, if they could be determined
+	Commits []Commit `json:"commits,omitempty"`
+	// ChartVersion describes the deployed chart, if it was installed from a Helm repository
+	ChartVersion *ChartVersion `json:"chartVersion,omitempty"`
+}
+
+// Version prefers the resolved revision of the deployment over the target revision of its source.
+func (entry History) Version() string {
+	if entry.Revision != "" {
+		return entry.Revision
+	}
+	return entry.Source.TargetRevision
+}
+
+type Commit struct {
+	Sha     string `json:"sha"`
+	Subject string `json:"subject"`
+	Author  string `json:"author,omitempty"`
+}
+
+// ChartVersion is the entry of a chart version in the index of its Helm repository.
+type ChartVersion struct {
+	Version    string   `json:"version"`
+	AppVersion string   `json:"appVersion,omitempty"`
+	Home       string   `json:"home,omitempty"`
+	Sources    []string `json:"sources,omitempty"`
+	Created    string   `json:"created,omitempty"`
 }
 
-type summary struct {
-	ExternalUrls         []string
-	Images               []string
-	LatestImage          bool
-	PostgresqlImageFound bool
-	PostgresqlImage      string
+type Summary struct {
+	ExternalUrls         []string `json:"externalURLs,omitempty"`
+	Images               []string `json:"images,omitempty"`
+	LatestImage          bool     `json:"latestImage,omitempty"`
+	PostgresqlImageFound bool     `json:"postgresqlImageFound,omitempty"`
+	PostgresqlImage      string   `json:"postgresqlImage,omitempty"`
+	// NewerChartVersion is the latest release in the Helm repository, if it is newer than the installed one
+	NewerChartVersion string `json:"newerChartVersion,omitempty"`
 }
 
-type statusSync struct {
-	Source Source
-	Status string
+type StatusSync struct {
+	Source Source `json:"source"`
+	Status string `json:"status,omitempty"`
 }
@@ -0,0 +1,148 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package changes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"dashboard/internal/app"
+	"dashboard/internal/forge"
+)
+
+// ApiSource fetches commits from the REST APIs of GitHub, GitLab and Gitea for hosts a token is configured for.
+type ApiSource struct {
+	client *http.Client
+	// tokens by host
+	tokens map[string]string
+}
+
+func NewApiSource(client *http.Client, tokens map[string]string) *ApiSource {
+	return &ApiSource{client: client, tokens: tokens}
+}
+
+type githubCompare struct {
+	Commits []struct {
+		Sha    string `json:"sha"`
+		Commit struct {
+			Message string `json:"message"`
+			Author  struct {
+				Name string `json:"name"`
+			} `json:"author"`
+		} `json:"commit"`
+	} `json:"commits"`
+}
+
+type gitlabCompare struct {
+	Commits []struct {
+		Id         string `json:"id"`
+		Title      string `json:"title"`
+		AuthorName string `json:"author_name"`
+	} `json:"commits"`
+}
+
+func (source *ApiSource) Commits(ctx context.Context, repository forge.Repository, from string, to string) ([]app.Commit, error) {
+	token, found := source.tokens[repository.Host]
+	if hostname, _, err := net.SplitHostPort(repository.Host); !found && err == nil {
+		// tokens may be configured without the port of the web interface
+		token, found = source.tokens[hostname]
+	}
+	if !found {
+		return nil, ErrUnsupported
+	}
+
+	switch repository.Kind {
+	case forge.GitHub, forge.Gitea:
+		var compare githubCompare
+		apiUrl := githubApiUrl(repository) + "/repos/" + repository.Path + "/compare/" + from + "..." + to
+		if repository.Kind == forge.Gitea {
+			apiUrl = "https://" + repository.Host + "/api/v1/repos/" + repository.Path + "/compare/" + from + "..." + to
+		}
+		if err := source.get(ctx, apiUrl, "Authorization", "token "+token, &compare); err != nil {
+			return nil, err
+		}
+
+		commits := make([]app.Commit, 0, len(compare.Commits))
+		// GitHub and Gitea list the oldest commit first
+		for i := len(compare.Commits) - 1; i >= 0; i-- {
+			commit := compare.Commits[i]
+			commits = append(commits, app.Commit{
+				Sha:     commit.Sha,
+				Subject: subjectOf(commit.Commit.Message),
+				Author:  commit.Commit.Author.Name,
+			})
+		}
+		return commits, nil
+	case forge.GitLab:
+		var compare gitlabCompare
+		apiUrl := "https://" + repository.Host + "/api/v4/projects/" + url.PathEscape(repository.Path) +
+			"/repository/compare?from=" + url.QueryEscape(from) + "&to=" + url.QueryEscape(to)
+		if err := source.get(ctx, apiUrl, "PRIVATE-TOKEN", token, &compare); err != nil {
+			return nil, err
+		}
+
+		commits := make([]app.Commit, 0, len(compare.Commits))
+		for i := len(compare.Commits) - 1; i >= 0; i-- {
+			commit := compare.Commits[i]
+			commits = append(commits, app.Commit{Sha: commit.Id, Subject: commit.Title, Author: commit.AuthorName})
+		}
+		return commits, nil
+	default:
+		return nil, ErrUnsupported
+	}
+}
+
+func (source *ApiSource) get(ctx context.Context, apiUrl string, authHeader string, authValue string, result any) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, apiUrl, nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set(authHeader, authValue)
+	request.Header.Set("Accept", "application/json")
+
+	response, err := source.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not compare revisions, %s answered with %s", apiUrl, response.Status)
+	}
+	return json.NewDecoder(response.Body).Decode(result)
+}
+
+// githubApiUrl returns the API of github.com or of a GitHub Enterprise server.
+func githubApiUrl(repository forge.Repository) string {
+	if repository.Host == "github.com" {
+		return "https://api.github.com"
+	}
+	return "https://" + repository.Host + "/api/v3"
+}
+
+func subjectOf(message string) string {
+	subject, _, _ := strings.Cut(message, "\n")
+	return strings.TrimSpace(subject)
+}
@@ -0,0 +1,254 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+// Package changes determines the commits a redeployment brought in, using the APIs of the forges or local mirrors.
+package changes
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"dashboard/internal/app"
+	"dashboard/internal/forge"
+)
+
+const (
+	// MaxCommits limits the commits kept per deployment, so huge comparisons don't bloat the dashboard
+	MaxCommits    = 20
+	maxCacheSize  = 5000
+	failureExpiry = 30 * time.Minute
+	// maxConcurrentFetches limits the requests sent to the forges at once
+	maxConcurrentFetches = 4
+	// syncBudget limits how long a sync waits for the commits of the latest deployments
+	syncBudget = 15 * time.Second
+)
+
+// ErrUnsupported is returned by sources that can't provide the commits of a repository.
+var ErrUnsupported = errors.New("repository not supported by source")
+
+// CommitSource lists the commits reachable from revision to, but not from revision from.
+type CommitSource interface {
+	Commits(ctx context.Context, repository forge.Repository, from string, to string) ([]app.Commit, error)
+}
+
+type cacheEntry struct {
+	commits  []app.Commit
+	failedAt time.Time
+}
+
+// comparison is the range of commits between two deployments.
+type comparison struct {
+	repository forge.Repository
+	from       string
+	to         string
+}
+
+func (comparison comparison) key() string {
+	return comparison.repository.WebUrl() + "@" + comparison.from + ".." + comparison.to
+}
+
+// Enricher adds the commits between consecutive deployments to the history of the applications. Sources are asked
+// in order until one supports the repository. Since commit ranges never change, results are cached.
+//
+// A sync only waits for the commits of the latest deployments, and at most syncBudget. Older deployments and
+// comparisons exceeding the budget are fetched in the background by Run and attached by the next sync.
+type Enricher struct {
+	resolver *forge.Resolver
+	sources  []CommitSource
+	mutex    sync.Mutex
+	cache    map[string]cacheEntry
+	pending  map[string]comparison
+	// wake triggers fetching pending comparisons
+	wake chan struct{}
+}
+
+func NewEnricher(resolver *forge.Resolver, sources ...CommitSource) *Enricher {
+	return &Enricher{
+		resolver: resolver,
+		sources:  sources,
+		cache:    map[string]cacheEntry{},
+		pending:  map[string]comparison{},
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+func (enricher *Enricher) Enrich(ctx context.Context, applications *app.Applications) error {
+	latest := map[string]comparison{}
+	for i := range applications.Items {
+		history := applications.Items[i].Status.History
+		sort.Slice(history, func(a, b int) bool {
+			return history[a].Id > history[b].Id
+		})
+
+		for j := 0; j+1 < len(history); j++ {
+			comparison, ok := enricher.comparisonOf(history[j+1], history[j])
+			if !ok {
+				continue
+			}
+			if _, found := enricher.cached(comparison.key()); found {
+				continue
+			}
+			if j == 0 {
+				latest[comparison.key()] = comparison
+			} else {
+				enricher.queue(comparison)
+			}
+		}
+	}
+
+	budgetCtx, cancel := context.WithTimeout(ctx, syncBudget)
+	defer cancel()
+	err := enricher.fetchAll(budgetCtx, latest)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	for i := range applications.Items {
+		history := applications.Items[i].Status.History
+		for j := 0; j+1 < len(history); j++ {
+			if comparison, ok := enricher.comparisonOf(history[j+1], history[j]); ok {
+				history[j].Commits, _ = enricher.cached(comparison.key())
+			}
+		}
+	}
+
+	enricher.mutex.Lock()
+	if len(enricher.pending) > 0 {
+		select {
+		case enricher.wake <- struct{}{}:
+		default:
+		}
+	}
+	enricher.mutex.Unlock()
+	return err
+}
+
+// Run fetches the pending comparisons in the background until the context is done.
+func (enricher *Enricher) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-enricher.wake:
+		}
+
+		enricher.mutex.Lock()
+		pending := enricher.pending
+		enricher.pending = map[string]comparison{}
+		enricher.mutex.Unlock()
+
+		if err := enricher.fetchAll(ctx, pending); err != nil && ctx.Err() == nil {
+			log.Printf("Could not read the commits of deployments: %v", err)
+		}
+	}
+}
+
+func (enricher *Enricher) comparisonOf(previous app.History, current app.History) (comparison, bool) {
+	if previous.Source.RepoUrl != current.Source.RepoUrl || previous.Revision == current.Revision ||
+		!forge.IsCommitHash(previous.Revision) || !forge.IsCommitHash(current.Revision) {
+		return comparison{}, false
+	}
+	repository, ok := enricher.resolver.Resolve(current.Source.RepoUrl)
+	if !ok {
+		return comparison{}, false
+	}
+	return comparison{repository: repository, from: previous.Revision, to: current.Revision}, true
+}
+
+// cached returns the commits of the comparison, failures are retried after failureExpiry.
+func (enricher *Enricher) cached(key string) ([]app.Commit, bool) {
+	enricher.mutex.Lock()
+	defer enricher.mutex.Unlock()
+	entry, found := enricher.cache[key]
+	if !found || (!entry.failedAt.IsZero() && time.Since(entry.failedAt) >= failureExpiry) {
+		return nil, false
+	}
+	return entry.commits, true
+}
+
+func (enricher *Enricher) queue(comparison comparison) {
+	enricher.mutex.Lock()
+	defer enricher.mutex.Unlock()
+	enricher.pending[comparison.key()] = comparison
+}
+
+// fetchAll fetches the comparisons with a few requests at once and caches the results. Comparisons cut off by the
+// context, i.e. the sync budget, are queued for the background.
+func (enricher *Enricher) fetchAll(ctx context.Context, comparisons map[string]comparison) error {
+	var errs []error
+	var errsMutex sync.Mutex
+	semaphore := make(chan struct{}, maxConcurrentFetches)
+	var wait sync.WaitGroup
+	for _, pending := range comparisons {
+		wait.Add(1)
+		semaphore <- struct{}{}
+		go func(pending comparison) {
+			defer wait.Done()
+			defer func() { <-semaphore }()
+			if err := enricher.fetch(ctx, pending); err != nil {
+				errsMutex.Lock()
+				errs = append(errs, err)
+				errsMutex.Unlock()
+			}
+		}(pending)
+	}
+	wait.Wait()
+	return errors.Join(errs...)
+}
+
+func (enricher *Enricher) fetch(ctx context.Context, comparison comparison) error {
+	commits, err := enricher.commits(ctx, comparison)
+	if errors.Is(err, ErrUnsupported) {
+		return nil
+	}
+	if ctx.Err() != nil {
+		enricher.queue(comparison)
+		return nil
+	}
+
+	entry := cacheEntry{commits: commits}
+	if err != nil {
+		entry = cacheEntry{failedAt: time.Now()}
+	}
+	enricher.mutex.Lock()
+	defer enricher.mutex.Unlock()
+	if len(enricher.cache) >= maxCacheSize {
+		enricher.cache = map[string]cacheEntry{}
+	}
+	enricher.cache[comparison.key()] = entry
+	return err
+}
+
+func (enricher *Enricher) commits(ctx context.Context, comparison comparison) ([]app.Commit, error) {
+	for _, source := range enricher.sources {
+		commits, err := source.Commits(ctx, comparison.repository, comparison.from, comparison.to)
+		if errors.Is(err, ErrUnsupported) {
+			continue
+		}
+		if len(commits) > MaxCommits {
+			commits = commits[:MaxCommits]
+		}
+		return commits, err
+	}
+	return nil, ErrUnsupported
+}
@@ -0,0 +1,315 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package changes
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"dashboard/internal/app"
+	"dashboard/internal/forge"
+)
+
+const (
+	firstRevision  = "c4232944d8e75ab1e23067f1cf4c88f51f82317e"
+	secondRevision = "b8d56b2d875b183f3109f645443373e18f56783b"
+	thirdRevision  = "30a8d5046b6fd2a4a2f73f2bdfbba0f0e6e6a8b1"
+	repoUrl        = "https://github.com/eclipse-tractusx/app-dashboard.git"
+)
+
+type fakeSource struct {
+	calls   int
+	commits []app.Commit
+	err     error
+}
+
+func (source *fakeSource) Commits(_ context.Context, _ forge.Repository, from string, to string) ([]app.Commit, error) {
+	source.calls++
+	if source.err != nil {
+		return nil, source.err
+	}
+	return append([]app.Commit{{Sha: to, Subject: from + ".." + to}}, source.commits...), nil
+}
+
+func givenApplicationWithHistory(revisions ...string) app.Applications {
+	var history []app.History
+	for i, revision := range revisions {
+		history = append(history, app.History{Id: i + 1, Revision: revision, Source: app.Source{RepoUrl: repoUrl}})
+	}
+	return app.Applications{Items: []app.Item{{Status: app.Status{History: history}}}}
+}
+
+func TestShouldAddCommitsBetweenConsecutiveDeployments(t *testing.T) {
+	source := &fakeSource{}
+	enricher := NewEnricher(forge.NewResolver(nil), source)
+	applications := givenApplicationWithHistory(firstRevision, secondRevision, thirdRevision)
+
+	err := enricher.Enrich(context.Background(), &applications)
+
+	history := applications.Items[0].Status.History
+	if err != nil {
+		t.Errorf("Enriching should not fail! \nexpected: %v \nGot: %v", nil, err)
+	}
+	if history[0].Revision != thirdRevision || history[0].Commits[0].Subject != secondRevision+".."+thirdRevision {
+		t.Errorf("Commits of latest deployment not added! \nexpected: %s \nGot: %v", secondRevision+".."+thirdRevision, history[0])
+	}
+	if history[1].Commits != nil {
+		t.Errorf("Older deployments should be fetched in the background! \nexpected: %v \nGot: %v", nil, history[1].Commits)
+	}
+
+	// the background run fetches the older deployments for the next sync
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		enricher.Run(ctx)
+		close(done)
+	}()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, found := enricher.cached(comparison{repository: mustResolve(t, repoUrl), from: firstRevision, to: secondRevision}.key()); found || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	<-done
+
+	applications = givenApplicationWithHistory(firstRevision, secondRevision, thirdRevision)
+	_ = enricher.Enrich(context.Background(), &applications)
+
+	history = applications.Items[0].Status.History
+	if len(history[1].Commits) == 0 || history[1].Commits[0].Subject != firstRevision+".."+secondRevision {
+		t.Errorf("Commits of second deployment not added! \nexpected: %s \nGot: %v", firstRevision+".."+secondRevision, history[1])
+	}
+	if history[2].Commits != nil {
+		t.Errorf("First deployment should not have commits! \nexpected: %v \nGot: %v", nil, history[2].Commits)
+	}
+	if source.calls != 2 {
+		t.Errorf("Each comparison should be fetched once! \nexpected: %d \nGot: %d", 2, source.calls)
+	}
+}
+
+func TestShouldQueueLatestDeploymentsExceedingTheSyncBudget(t *testing.T) {
+	source := &fakeSource{}
+	enricher := NewEnricher(forge.NewResolver(nil), source)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_ = enricher.fetchAll(ctx, map[string]comparison{"": {repository: mustResolve(t, repoUrl), from: firstRevision, to: secondRevision}})
+
+	if len(enricher.pending) != 1 || len(enricher.cache) != 0 {
+		t.Errorf("Comparison cut off by the budget should be queued, not cached! \nexpected: %d \nGot: %d, %d", 1, len(enricher.pending), len(enricher.cache))
+	}
+}
+
+func mustResolve(t *testing.T, repoUrl string) forge.Repository {
+	repository, ok := forge.NewResolver(nil).Resolve(repoUrl)
+	if !ok {
+		t.Fatalf("Could not resolve %s", repoUrl)
+	}
+	return repository
+}
+
+func TestShouldCacheCommitsBetweenRevisions(t *testing.T) {
+	source := &fakeSource{}
+	enricher := NewEnricher(forge.NewResolver(nil), source)
+
+	for i := 0; i < 3; i++ {
+		applications := givenApplicationWithHistory(firstRevision, secondRevision)
+		_ = enricher.Enrich(context.Background(), &applications)
+		if len(applications.Items[0].Status.History[0].Commits) != 1 {
+			t.Errorf("Cached commits not added! \nexpected: %d \nGot: %v", 1, applications.Items[0].Status.History[0].Commits)
+		}
+	}
+
+	if source.calls != 1 {
+		t.Errorf("Commits should only be fetched once! \nexpected: %d \nGot: %d", 1, source.calls)
+	}
+}
+
+func TestShouldCacheFailuresAndReportThemOnce(t *testing.T) {
+	source := &fakeSource{err: errors.New("rate limited")}
+	enricher := NewEnricher(forge.NewResolver(nil), source)
+
+	applications := givenApplicationWithHistory(firstRevision, secondRevision)
+	firstErr := enricher.Enrich(context.Background(), &applications)
+	secondErr := enricher.Enrich(context.Background(), &applications)
+
+	if firstErr == nil || secondErr != nil {
+		t.Errorf("Failure should be reported once! \nexpected: %s \nGot: %v, %v", "rate limited, <nil>", firstErr, secondErr)
+	}
+	if source.calls != 1 {
+		t.Errorf("Failed comparison should not be retried immediately! \nexpected: %d \nGot: %d", 1, source.calls)
+	}
+}
+
+func TestShouldSkipRevisionsThatAreNoCommits(t *testing.T) {
+	source := &fakeSource{}
+	applications := givenApplicationWithHistory("1.0.0", "main", secondRevision)
+
+	_ = NewEnricher(forge.NewResolver(nil), source).Enrich(context.Background(), &applications)
+
+	if source.calls != 0 {
+		t.Errorf("Tags and branches should not be compared! \nexpected: %d \nGot: %d", 0, source.calls)
+	}
+}
+
+func TestShouldLimitCommitsPerDeployment(t *testing.T) {
+	source := &fakeSource{commits: make([]app.Commit, 2*MaxCommits)}
+	applications := givenApplicationWithHistory(firstRevision, secondRevision)
+
+	_ = NewEnricher(forge.NewResolver(nil), source).Enrich(context.Background(), &applications)
+
+	if commits := applications.Items[0].Status.History[0].Commits; len(commits) != MaxCommits {
+		t.Errorf("Commits not limited! \nexpected: %d \nGot: %d", MaxCommits, len(commits))
+	}
+}
+
+// redirectingTransport sends all requests to the test server, since the API source only talks to the real forge hosts.
+type redirectingTransport struct {
+	server *httptest.Server
+}
+
+func (transport redirectingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	target, _ := url.Parse(transport.server.URL)
+	request.URL.Scheme = target.Scheme
+	request.URL.Host = target.Host
+	return transport.server.Client().Transport.RoundTrip(request)
+}
+
+func TestShouldReadCommitsFromGithubApi(t *testing.T) {
+	var requestedPath, authorization string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPath, authorization = r.URL.Path, r.Header.Get("Authorization")
+		_, _ = w.Write([]byte(`{"commits": [
+			{"sha": "a1", "commit": {"message": "Older change\n\nWith details", "author": {"name": "Jane Doe"}}},
+			{"sha": "b2", "commit": {"message": "Newer change", "author": {"name": "John Doe"}}}]}`))
+	}))
+	defer server.Close()
+
+	source := NewApiSource(&http.Client{Transport: redirectingTransport{server}}, map[string]string{"github.com": "secret"})
+	repository := forge.Repository{Kind: forge.GitHub, Host: "github.com", Path: "eclipse-tractusx/app-dashboard"}
+	commits, err := source.Commits(context.Background(), repository, firstRevision, secondRevision)
+
+	expected := []app.Commit{{Sha: "b2", Subject: "Newer change", Author: "John Doe"}, {Sha: "a1", Subject: "Older change", Author: "Jane Doe"}}
+	if err != nil || !reflect.DeepEqual(commits, expected) {
+		t.Errorf("Commits not read from GitHub! \nexpected: %v \nGot: %v, %v", expected, commits, err)
+	}
+	if expectedPath := "/repos/eclipse-tractusx/app-dashboard/compare/" + firstRevision + "..." + secondRevision; requestedPath != expectedPath {
+		t.Errorf("Wrong compare API requested! \nexpected: %s \nGot: %s", expectedPath, requestedPath)
+	}
+	if authorization != "token secret" {
+		t.Errorf("Token not sent! \nexpected: %s \nGot: %s", "token secret", authorization)
+	}
+}
+
+func TestShouldReadCommitsFromGitlabApi(t *testing.T) {
+	var requestedUri, privateToken string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedUri, privateToken = r.URL.RequestURI(), r.Header.Get("PRIVATE-TOKEN")
+		_, _ = w.Write([]byte(`{"commits": [{"id": "a1", "title": "Older change", "author_name": "Jane Doe"},
+			{"id": "b2", "title": "Newer change", "author_name": "John Doe"}]}`))
+	}))
+	defer server.Close()
+
+	source := NewApiSource(&http.Client{Transport: redirectingTransport{server}}, map[string]string{"gitlab.com": "secret"})
+	repository := forge.Repository{Kind: forge.GitLab, Host: "gitlab.com", Path: "group/project"}
+	commits, err := source.Commits(context.Background(), repository, firstRevision, secondRevision)
+
+	expected := []app.Commit{{Sha: "b2", Subject: "Newer change", Author: "John Doe"}, {Sha: "a1", Subject: "Older change", Author: "Jane Doe"}}
+	if err != nil || !reflect.DeepEqual(commits, expected) {
+		t.Errorf("Commits not read from GitLab! \nexpected: %v \nGot: %v, %v", expected, commits, err)
+	}
+	if expectedUri := "/api/v4/projects/group%2Fproject/repository/compare?from=" + firstRevision + "&to=" + secondRevision; requestedUri != expectedUri {
+		t.Errorf("Wrong compare API requested! \nexpected: %s \nGot: %s", expectedUri, requestedUri)
+	}
+	if privateToken != "secret" {
+		t.Errorf("Token not sent! \nexpected: %s \nGot: %s", "secret", privateToken)
+	}
+}
+
+func TestShouldNotSupportHostsWithoutToken(t *testing.T) {
+	source := NewApiSource(http.DefaultClient, map[string]string{"gitlab.com": "secret"})
+	repository := forge.Repository{Kind: forge.GitHub, Host: "github.com", Path: "eclipse-tractusx/app-dashboard"}
+
+	_, err := source.Commits(context.Background(), repository, firstRevision, secondRevision)
+
+	if !errors.Is(err, ErrUnsupported) {
+		t.Errorf("Host without token should not be supported! \nexpected: %v \nGot: %v", ErrUnsupported, err)
+	}
+}
+
+func TestShouldReadCommitsFromMirror(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	repositoryDir := dir + "/github.com/eclipse-tractusx/app-dashboard.git"
+	git := func(args ...string) string {
+		command := exec.Command("git", append([]string{"-C", repositoryDir, "-c", "user.name=Jane Doe", "-c", "user.email=jane@example.org"}, args...)...)
+		output, err := command.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v failed: %v: %s", args, err, output)
+		}
+		return strings.TrimSpace(string(output))
+	}
+	if output, err := exec.Command("git", "init", "-q", repositoryDir).CombinedOutput(); err != nil {
+		t.Fatalf("git init failed: %v: %s", err, output)
+	}
+	git("commit", "-q", "--allow-empty", "-m", "Initial")
+	from := git("rev-parse", "HEAD")
+	git("commit", "-q", "--allow-empty", "-m", "First change")
+	git("commit", "-q", "--allow-empty", "-m", "Second change")
+	to := git("rev-parse", "HEAD")
+
+	repository := forge.Repository{Kind: forge.GitHub, Host: "github.com", Path: "eclipse-tractusx/app-dashboard"}
+	commits, err := NewMirrorSource(dir).Commits(context.Background(), repository, from, to)
+
+	if err != nil || len(commits) != 2 || commits[0].Sha != to || commits[0].Subject != "Second change" || commits[1].Author != "Jane Doe" {
+		t.Errorf("Commits not read from mirror! \nexpected: %s \nGot: %v, %v", "Second change, First change", commits, err)
+	}
+}
+
+func TestShouldNotReadMirrorsOutsideTheMirrorDirectory(t *testing.T) {
+	parent := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(parent, "outside.git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Join(parent, "mirrors")
+
+	for _, repository := range []forge.Repository{
+		{Kind: forge.GitHub, Host: "github.com", Path: "../../outside"},
+		{Kind: forge.GitHub, Host: "..", Path: "outside"},
+	} {
+		if _, err := NewMirrorSource(dir).Commits(context.Background(), repository, firstRevision, secondRevision); !errors.Is(err, ErrUnsupported) {
+			t.Errorf("Mirror outside the mirror directory should not be read! \nexpected: %v \nGot: %v", ErrUnsupported, err)
+		}
+	}
+}
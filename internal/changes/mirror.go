@@ -0,0 +1,85 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package changes
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"dashboard/internal/app"
+	"dashboard/internal/forge"
+)
+
+// MirrorSource reads commits from local clones, expected in <dir>/<host>/<path>.git or <dir>/<host>/<path>.
+// Keeping the mirrors up to date, i.e. with "git remote update", is up to the operator.
+type MirrorSource struct {
+	dir string
+}
+
+func NewMirrorSource(dir string) *MirrorSource {
+	return &MirrorSource{dir: dir}
+}
+
+func (source *MirrorSource) Commits(ctx context.Context, repository forge.Repository, from string, to string) ([]app.Commit, error) {
+	repositoryDir, found := source.find(repository)
+	if !found {
+		return nil, ErrUnsupported
+	}
+
+	var stdout, stderr bytes.Buffer
+	command := exec.CommandContext(ctx, "git", "-C", repositoryDir, "log", "--format=%H%x09%an%x09%s",
+		fmt.Sprintf("--max-count=%d", MaxCommits), from+".."+to)
+	command.Stdout = &stdout
+	command.Stderr = &stderr
+	if err := command.Run(); err != nil {
+		return nil, fmt.Errorf("could not read commits from mirror %s: %w: %s", repositoryDir, err, strings.TrimSpace(stderr.String()))
+	}
+
+	var commits []app.Commit
+	for _, line := range strings.Split(strings.TrimSpace(stdout.String()), "\n") {
+		parts := strings.SplitN(line, "\t", 3)
+		if len(parts) == 3 {
+			commits = append(commits, app.Commit{Sha: parts[0], Author: parts[1], Subject: parts[2]})
+		}
+	}
+	return commits, nil
+}
+
+// find rejects repositories outside the mirror directory, since their path comes from the repository URL of an
+// application and may contain "..".
+func (source *MirrorSource) find(repository forge.Repository) (string, bool) {
+	root := filepath.Clean(source.dir)
+	base := filepath.Join(root, repository.Host, filepath.FromSlash(repository.Path))
+	relative, err := filepath.Rel(root, base)
+	if err != nil || relative == "." || relative == ".." || strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	for _, candidate := range []string{base + ".git", base} {
+		if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+			return candidate, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,181 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+// Package charts resolves the versions of charts installed from Helm repositories through the repository index.
+package charts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/yaml"
+
+	"dashboard/internal/app"
+	"dashboard/internal/semver"
+)
+
+// maxIndexSize protects against unreasonably large repository indexes, the biggest public ones have some ten MB.
+const maxIndexSize = 64 << 20
+
+type index struct {
+	Entries map[string][]app.ChartVersion `json:"entries"`
+}
+
+type cachedIndex struct {
+	index     *index
+	fetchedAt time.Time
+}
+
+// Enricher adds the index entries of the deployed chart versions to the history of the applications and flags
+// applications for which a newer chart version was released. Indexes are cached for the refresh interval.
+type Enricher struct {
+	client  *http.Client
+	refresh time.Duration
+	mutex   sync.Mutex
+	cache   map[string]cachedIndex
+}
+
+func NewEnricher(client *http.Client, refresh time.Duration) *Enricher {
+	return &Enricher{client: client, refresh: refresh, cache: map[string]cachedIndex{}}
+}
+
+func (enricher *Enricher) Enrich(ctx context.Context, applications *app.Applications) error {
+	var errs []error
+	failedRepositories := map[string]bool{}
+	lookup := func(source app.Source) *index {
+		if source.Chart == "" || failedRepositories[source.RepoUrl] {
+			return nil
+		}
+		index, err := enricher.index(ctx, source.RepoUrl)
+		if err != nil {
+			failedRepositories[source.RepoUrl] = true
+			errs = append(errs, err)
+		}
+		return index
+	}
+
+	for i := range applications.Items {
+		item := &applications.Items[i]
+		for j := range item.Status.History {
+			entry := &item.Status.History[j]
+			if index := lookup(entry.Source); index != nil {
+				entry.ChartVersion = index.find(entry.Source.Chart, entry.Version())
+			}
+		}
+
+		if index := lookup(item.Spec.Source); index != nil {
+			item.Status.Summary.NewerChartVersion = index.newerVersion(item.Spec.Source.Chart, item.DeployedVersion())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (index *index) find(chart string, version string) *app.ChartVersion {
+	for _, entry := range index.Entries[chart] {
+		if entry.Version == version {
+			return &entry
+		}
+	}
+	return nil
+}
+
+// newerVersion returns the highest version of the chart, if it is higher than the installed one. Prereleases are
+// only considered if a prerelease is installed.
+func (index *index) newerVersion(chart string, installed string) string {
+	current, ok := semver.Parse(installed)
+	if !ok {
+		return ""
+	}
+
+	newer, newest := "", current
+	for _, entry := range index.Entries[chart] {
+		version, ok := semver.Parse(entry.Version)
+		if !ok || (version.IsPrerelease() && !current.IsPrerelease()) {
+			continue
+		}
+		if version.Compare(newest) > 0 {
+			newer, newest = entry.Version, version
+		}
+	}
+	return newer
+}
+
+// index returns the cached index of the repository, fetching it if the cache expired. If fetching fails, the
+// outdated index is kept until the next refresh.
+func (enricher *Enricher) index(ctx context.Context, repoUrl string) (*index, error) {
+	if !strings.HasPrefix(repoUrl, "https://") && !strings.HasPrefix(repoUrl, "http://") {
+		// OCI registries have no index
+		return nil, nil
+	}
+
+	enricher.mutex.Lock()
+	cached, found := enricher.cache[repoUrl]
+	enricher.mutex.Unlock()
+	if found && time.Since(cached.fetchedAt) < enricher.refresh {
+		return cached.index, nil
+	}
+
+	fetched, err := enricher.fetch(ctx, repoUrl)
+	if ctx.Err() != nil {
+		return cached.index, ctx.Err()
+	}
+	if err == nil {
+		cached.index = fetched
+	}
+	cached.fetchedAt = time.Now()
+
+	enricher.mutex.Lock()
+	enricher.cache[repoUrl] = cached
+	enricher.mutex.Unlock()
+	return cached.index, err
+}
+
+func (enricher *Enricher) fetch(ctx context.Context, repoUrl string) (*index, error) {
+	indexUrl := strings.TrimSuffix(repoUrl, "/") + "/index.yaml"
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, indexUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := enricher.client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch chart index: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not fetch chart index, %s answered with %s", indexUrl, response.Status)
+	}
+	raw, err := io.ReadAll(io.LimitReader(response.Body, maxIndexSize))
+	if err != nil {
+		return nil, fmt.Errorf("could not read chart index %s: %w", indexUrl, err)
+	}
+
+	var result index
+	if err := yaml.Unmarshal(raw, &result); err != nil {
+		return nil, fmt.Errorf("invalid chart index %s: %w", indexUrl, err)
+	}
+	return &result, nil
+}
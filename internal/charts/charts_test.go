@@ -0,0 +1,180 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package charts
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"dashboard/internal/app"
+)
+
+const chartIndex = `apiVersion: v1
+entries:
+  tractusx-connector:
+  - apiVersion: v2
+    appVersion: 0.6.0
+    created: "2023-10-05T10:52:35.123456789Z"
+    home: https://github.com/eclipse-tractusx/tractusx-edc
+    name: tractusx-connector
+    sources:
+    - https://github.com/eclipse-tractusx/tractusx-edc/tree/main/charts/tractusx-connector
+    version: 0.6.0
+  - appVersion: 0.7.0-rc1
+    created: "2023-10-20T08:00:00Z"
+    name: tractusx-connector
+    version: 0.7.0-rc1
+  - appVersion: 0.5.1
+    created: 2023-09-01T08:00:00Z
+    name: tractusx-connector
+    version: 0.5.1
+  - appVersion: 0.5.0
+    created: "2023-08-01T08:00:00Z"
+    name: tractusx-connector
+    version: 0.5.0
+generated: "2023-10-20T08:00:00Z"
+`
+
+func givenChartRepository(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "index.yaml"), []byte(chartIndex), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	requests := &atomic.Int32{}
+	files := http.FileServer(http.Dir(dir))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		files.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server, requests
+}
+
+func givenChartApplication(repoUrl string, revisions ...string) app.Applications {
+	item := app.Item{Spec: app.Spec{Source: app.Source{RepoUrl: repoUrl, Chart: "tractusx-connector", TargetRevision: "0.5.*"}}}
+	for i, revision := range revisions {
+		item.Status.History = append(item.Status.History, app.History{
+			Id:       i + 1,
+			Revision: revision,
+			Source:   item.Spec.Source,
+		})
+	}
+	return app.Applications{Items: []app.Item{item}}
+}
+
+func TestShouldAddIndexEntriesOfDeployedChartVersions(t *testing.T) {
+	server, _ := givenChartRepository(t)
+	applications := givenChartApplication(server.URL+"/", "0.5.0", "0.6.0")
+
+	err := NewEnricher(server.Client(), time.Hour).Enrich(context.Background(), &applications)
+
+	history := applications.Items[0].Status.History
+	if err != nil {
+		t.Errorf("Enriching should not fail! \nexpected: %v \nGot: %v", nil, err)
+	}
+	if history[0].ChartVersion == nil || history[0].ChartVersion.AppVersion != "0.5.0" {
+		t.Errorf("Chart version of first deployment not added! \nexpected: %s \nGot: %v", "0.5.0", history[0].ChartVersion)
+	}
+	latest := history[1].ChartVersion
+	if latest == nil || latest.Home != "https://github.com/eclipse-tractusx/tractusx-edc" || latest.Created != "2023-10-05T10:52:35.123456789Z" ||
+		len(latest.Sources) != 1 {
+		t.Errorf("Chart version of latest deployment not added! \nexpected: %s \nGot: %v", "0.6.0 with home, sources and creation date", latest)
+	}
+}
+
+func TestShouldFlagNewerStableChartVersion(t *testing.T) {
+	server, _ := givenChartRepository(t)
+	applications := givenChartApplication(server.URL, "0.5.0")
+
+	_ = NewEnricher(server.Client(), time.Hour).Enrich(context.Background(), &applications)
+
+	if newer := applications.Items[0].Status.Summary.NewerChartVersion; newer != "0.6.0" {
+		t.Errorf("Newer chart version not flagged! \nexpected: %s \nGot: %s", "0.6.0", newer)
+	}
+}
+
+func TestShouldNotFlagLatestChartVersion(t *testing.T) {
+	server, _ := givenChartRepository(t)
+	applications := givenChartApplication(server.URL, "0.6.0")
+
+	_ = NewEnricher(server.Client(), time.Hour).Enrich(context.Background(), &applications)
+
+	if newer := applications.Items[0].Status.Summary.NewerChartVersion; newer != "" {
+		t.Errorf("Latest chart version should not be flagged! \nexpected: %s \nGot: %s", "", newer)
+	}
+}
+
+func TestShouldConsiderPrereleasesIfPrereleaseIsInstalled(t *testing.T) {
+	server, _ := givenChartRepository(t)
+	applications := givenChartApplication(server.URL)
+	applications.Items[0].Spec.Source.TargetRevision = "0.6.0-rc1"
+
+	_ = NewEnricher(server.Client(), time.Hour).Enrich(context.Background(), &applications)
+
+	if newer := applications.Items[0].Status.Summary.NewerChartVersion; newer != "0.7.0-rc1" {
+		t.Errorf("Newer prerelease not flagged! \nexpected: %s \nGot: %s", "0.7.0-rc1", newer)
+	}
+}
+
+func TestShouldCacheIndexUntilRefresh(t *testing.T) {
+	server, requests := givenChartRepository(t)
+	enricher := NewEnricher(server.Client(), time.Hour)
+
+	for i := 0; i < 3; i++ {
+		applications := givenChartApplication(server.URL, "0.5.0", "0.6.0")
+		_ = enricher.Enrich(context.Background(), &applications)
+	}
+
+	if requests.Load() != 1 {
+		t.Errorf("Index should only be fetched once! \nexpected: %d \nGot: %d", 1, requests.Load())
+	}
+}
+
+func TestShouldReportMissingIndexOncePerRepository(t *testing.T) {
+	server, requests := givenChartRepository(t)
+	applications := givenChartApplication(server.URL+"/missing", "0.5.0", "0.6.0")
+
+	err := NewEnricher(server.Client(), time.Hour).Enrich(context.Background(), &applications)
+
+	if err == nil || requests.Load() != 1 {
+		t.Errorf("Missing index should be reported once! \nexpected: %d request and an error \nGot: %d, %v", 1, requests.Load(), err)
+	}
+	if applications.Items[0].Status.History[0].ChartVersion != nil {
+		t.Errorf("No chart version expected without index! Got: %v", applications.Items[0].Status.History[0].ChartVersion)
+	}
+}
+
+func TestShouldIgnoreOciRepositoriesAndGitSources(t *testing.T) {
+	applications := givenChartApplication("registry-1.docker.io/bitnamicharts", "12.1.0")
+	applications.Items = append(applications.Items, app.Item{Spec: app.Spec{Source: app.Source{RepoUrl: "https://github.com/eclipse-tractusx/app-dashboard", Path: "charts"}}})
+
+	err := NewEnricher(http.DefaultClient, time.Hour).Enrich(context.Background(), &applications)
+
+	if err != nil || applications.Items[0].Status.History[0].ChartVersion != nil {
+		t.Errorf("OCI repositories and git sources should be skipped! \nexpected: %v \nGot: %v", nil, err)
+	}
+}
@@ -0,0 +1,202 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"dashboard/internal/app"
+)
+
+type rule struct {
+	name        string
+	description string
+	check       func(item app.Item) []string
+}
+
+type violation struct {
+	key     string
+	rule    string
+	message string
+}
+
+var rules = []rule{
+	{
+		name:        "no-latest-image",
+		description: "No container image uses a :latest or :main tag",
+		check: func(item app.Item) []string {
+			var messages []string
+			for _, image := range item.Status.Summary.Images {
+				if app.IsFloatingImageTag(image) {
+					messages = append(messages, "uses image "+image)
+				}
+			}
+			return messages
+		},
+	},
+	{
+		name:        "healthy",
+		description: "The application is reported as Healthy by Argo CD",
+		check: func(item app.Item) []string {
+			if item.Status.Health.Status != "Healthy" {
+				return []string{"health is " + valueOrNone(item.Status.Health.Status)}
+			}
+			return nil
+		},
+	},
+	{
+		name:        "synced",
+		description: "The application is in sync with its source",
+		check: func(item app.Item) []string {
+			if item.Status.Sync.Status != "Synced" {
+				return []string{"sync status is " + valueOrNone(item.Status.Sync.Status)}
+			}
+			return nil
+		},
+	},
+	{
+		name:        "no-errors",
+		description: "The application has no error conditions and its last sync operation did not fail",
+		check: func(item app.Item) []string {
+			var messages []string
+			for _, condition := range item.Status.Conditions {
+				if condition.IsError() {
+					messages = append(messages, "has condition "+condition.Type+": "+condition.Message)
+				}
+			}
+			if state := item.Status.OperationState; state != nil && state.IsFailed() {
+				messages = append(messages, "last operation "+state.Phase+": "+state.Message)
+			}
+			return messages
+		},
+	},
+}
+
+func check(args []string) int {
+	flags := newFlagSet("check", os.Stderr)
+	cluster := registerClusterFlags(flags)
+	snapshotFile := flags.String("snapshot", "", "Check the applications of a snapshot file instead of the cluster.")
+	ruleNames := flags.String("rules", strings.Join(allRuleNames(), ","), "Comma separated list of rules to evaluate.")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: dashboard check [flags]\n\nRules:")
+		for _, r := range rules {
+			fmt.Fprintf(flags.Output(), "  %-16s %s\n", r.name, r.description)
+		}
+		fmt.Fprintln(flags.Output(), "\nFlags:")
+		flags.PrintDefaults()
+	}
+	if code, ok := parseFlags(flags, args); !ok {
+		return code
+	}
+
+	selectedRules, err := selectRules(*ruleNames)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	var applications app.Applications
+	if *snapshotFile != "" {
+		if applications, err = readSnapshot(*snapshotFile); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return exitError
+		}
+		// applications read from the cluster are filtered by the gateway
+		applicationFilter, err := cluster.newFilter()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return exitError
+		}
+		applicationFilter.Apply(&applications)
+	} else {
+		ctx, stop := signalContext()
+		defer stop()
+
+		if applications, err = cluster.getApplications(ctx); err != nil {
+			fmt.Fprintf(os.Stderr, "Could not get applications: %v\n", err)
+			return exitError
+		}
+	}
+
+	violations := checkApplications(applications, selectedRules)
+	printViolations(os.Stdout, violations)
+
+	if len(violations) > 0 {
+		return exitFindings
+	}
+	return exitOk
+}
+
+func checkApplications(applications app.Applications, selectedRules []rule) []violation {
+	var violations []violation
+	for _, item := range applications.Items {
+		for _, r := range selectedRules {
+			for _, message := range r.check(item) {
+				violations = append(violations, violation{key: applicationKey(item), rule: r.name, message: message})
+			}
+		}
+	}
+	return violations
+}
+
+func selectRules(names string) ([]rule, error) {
+	var selected []rule
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		r, found := ruleByName(name)
+		if !found {
+			return nil, fmt.Errorf("unknown rule %q, available rules: %s", name, strings.Join(allRuleNames(), ", "))
+		}
+		selected = append(selected, r)
+	}
+	return selected, nil
+}
+
+func ruleByName(name string) (rule, bool) {
+	for _, r := range rules {
+		if r.name == name {
+			return r, true
+		}
+	}
+	return rule{}, false
+}
+
+func allRuleNames() []string {
+	names := make([]string, 0, len(rules))
+	for _, r := range rules {
+		names = append(names, r.name)
+	}
+	return names
+}
+
+func printViolations(out io.Writer, violations []violation) {
+	for _, v := range violations {
+		fmt.Fprintf(out, "%s: [%s] %s\n", v.key, v.rule, v.message)
+	}
+	if len(violations) > 0 {
+		fmt.Fprintf(out, "\n%d violation(s) found\n", len(violations))
+	}
+}
@@ -0,0 +1,82 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package cli
+
+import (
+	"dashboard/internal/app"
+	"reflect"
+	"testing"
+)
+
+func TestShouldReportNoViolationsForHealthySyncedApplication(t *testing.T) {
+	item := applicationItem("argocd", "irs", "Healthy", "Synced")
+	item.Status.Summary.Images = []string{"tractusx/irs:1.0.0"}
+
+	violations := checkApplications(app.Applications{Items: []app.Item{item}}, rules)
+
+	if len(violations) != 0 {
+		t.Errorf("Expected no violations! Got: %v", violations)
+	}
+}
+
+func TestShouldReportViolationsOfAllRules(t *testing.T) {
+	item := applicationItem("argocd", "irs", "Degraded", "OutOfSync")
+	item.Status.Summary.Images = []string{"tractusx/irs:latest", "tractusx/irs-frontend:main"}
+	item.Status.Conditions = []app.Condition{
+		{Type: "ComparisonError", Message: "repository not accessible"},
+		{Type: "SharedResourceWarning", Message: "ConfigMap is part of other applications"},
+	}
+	item.Status.OperationState = &app.OperationState{Phase: "Failed", Message: "one or more objects failed to apply"}
+
+	violations := checkApplications(app.Applications{Items: []app.Item{item}}, rules)
+
+	expected := []violation{
+		{key: "argocd/irs", rule: "no-latest-image", message: "uses image tractusx/irs:latest"},
+		{key: "argocd/irs", rule: "no-latest-image", message: "uses image tractusx/irs-frontend:main"},
+		{key: "argocd/irs", rule: "healthy", message: "health is Degraded"},
+		{key: "argocd/irs", rule: "synced", message: "sync status is OutOfSync"},
+		{key: "argocd/irs", rule: "no-errors", message: "has condition ComparisonError: repository not accessible"},
+		{key: "argocd/irs", rule: "no-errors", message: "last operation Failed: one or more objects failed to apply"},
+	}
+	if !reflect.DeepEqual(violations, expected) {
+		t.Errorf("Violations not reported correctly! \nexpected: %v \nGot: %v", expected, violations)
+	}
+}
+
+func TestShouldOnlyEvaluateSelectedRules(t *testing.T) {
+	item := applicationItem("argocd", "irs", "Degraded", "OutOfSync")
+	selectedRules, err := selectRules("synced")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	violations := checkApplications(app.Applications{Items: []app.Item{item}}, selectedRules)
+
+	expected := []violation{{key: "argocd/irs", rule: "synced", message: "sync status is OutOfSync"}}
+	if !reflect.DeepEqual(violations, expected) {
+		t.Errorf("Unselected rules were evaluated! \nexpected: %v \nGot: %v", expected, violations)
+	}
+}
+
+func TestShouldRejectUnknownRules(t *testing.T) {
+	if _, err := selectRules("healthy,unknown"); err == nil {
+		t.Errorf("Expected an error for an unknown rule!")
+	}
+}
@@ -0,0 +1,259 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+// Package cli implements the subcommands of the dashboard binary.
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"dashboard/internal/app"
+	"dashboard/internal/filter"
+	"dashboard/internal/gateway"
+)
+
+const (
+	exitOk       = 0
+	exitFindings = 1
+	exitError    = 2
+)
+
+const usage = `Usage: dashboard <command> [flags]
+
+Commands:
+  serve     Serve the dashboard and keep the applications in sync (default)
+  snapshot  Write the currently installed applications as JSON or YAML
+  diff      Compare two snapshot files, exits with 1 if they differ
+  check     Evaluate hygiene rules, exits with 1 on violations
+
+Use "dashboard <command> -h" for the flags of a command.
+`
+
+// Run executes the command given in args and returns the exit code for the process.
+func Run(args []string) int {
+	// Flags without a command start the server to keep "dashboard -in-cluster=true" working
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return serve(args)
+	}
+
+	switch args[0] {
+	case "serve":
+		return serve(args[1:])
+	case "snapshot":
+		return snapshot(args[1:])
+	case "diff":
+		return diff(args[1:])
+	case "check":
+		return check(args[1:])
+	case "help":
+		fmt.Fprint(os.Stdout, usage)
+		return exitOk
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown command %q\n\n%s", args[0], usage)
+		return exitError
+	}
+}
+
+type clusterFlags struct {
+	inCluster    bool
+	kubeconfig   string
+	podStatus    bool
+	events       bool
+	eventWindow  time.Duration
+	eventLimit   int
+	discoverUrls bool
+	filterConfig string
+	// applicationNamespaces is a comma separated list, empty for all namespaces
+	applicationNamespaces string
+	pageSize              int
+	toolNamespace         string
+	argoCdServer          string
+	argoCdProjects        string
+	argoCdInsecure        bool
+	flux                  bool
+	// fixture is a snapshot file or directory replayed instead of reading a cluster
+	fixture string
+	// err reports invalid defaults from the environment once the gateway is created
+	err error
+}
+
+func registerClusterFlags(flags *flag.FlagSet) *clusterFlags {
+	cluster := &clusterFlags{}
+	flags.BoolVar(&cluster.inCluster, "in-cluster", false, "Specify if the code is running inside a cluster or from outside.")
+	flags.StringVar(&cluster.kubeconfig, "kubeconfig", gateway.DefaultKubeconfig(), "(optional) absolute path to the kubeconfig file")
+	flags.BoolVar(&cluster.podStatus, "pod-status", os.Getenv("POD_STATUS") == "true",
+		"Read the pods running in the destination namespaces; requires permissions to list pods. Env: POD_STATUS")
+	flags.BoolVar(&cluster.events, "events", os.Getenv("EVENTS") == "true",
+		"Read recent Warning events of the destination namespaces; requires permissions to list events. Env: EVENTS")
+	eventWindow, err := getDurationFromEnv("EVENT_WINDOW", time.Hour)
+	cluster.err = errors.Join(cluster.err, err)
+	flags.DurationVar(&cluster.eventWindow, "event-window", eventWindow, "How long ago events may have been seen last to be shown. Env: EVENT_WINDOW")
+	eventLimit, err := getIntFromEnv("EVENT_LIMIT", 20)
+	cluster.err = errors.Join(cluster.err, err)
+	flags.IntVar(&cluster.eventLimit, "event-limit", eventLimit, "Maximum number of events shown per application. Env: EVENT_LIMIT")
+	flags.BoolVar(&cluster.discoverUrls, "discover-urls", os.Getenv("DISCOVER_URLS") == "true",
+		"Discover external URLs from the Ingresses, HTTPRoutes and OpenShift Routes of the applications; requires permissions to get them. Env: DISCOVER_URLS")
+	flags.StringVar(&cluster.filterConfig, "filter-config", os.Getenv("FILTER_CONFIG"),
+		"YAML or JSON file with include and exclude rules selecting the applications shown. Env: FILTER_CONFIG")
+	flags.StringVar(&cluster.applicationNamespaces, "application-namespaces", os.Getenv("APPLICATION_NAMESPACES"),
+		"Comma separated namespaces to list the applications of, so namespaced roles suffice; by default all namespaces. Env: APPLICATION_NAMESPACES")
+	pageSize, err := getIntFromEnv("PAGE_SIZE", gateway.DefaultPageSize)
+	cluster.err = errors.Join(cluster.err, err)
+	flags.IntVar(&cluster.pageSize, "page-size", pageSize,
+		"Number of applications listed per request to the Kubernetes API; 0 lists all at once. Env: PAGE_SIZE")
+	flags.StringVar(&cluster.toolNamespace, "tool-namespace", os.Getenv("TOOL_NAMESPACE"),
+		"Namespace Argo CD or Flux is installed in, to show its version; by default argocd or flux-system. Env: TOOL_NAMESPACE")
+	flags.StringVar(&cluster.argoCdServer, "argocd-server", os.Getenv("ARGOCD_SERVER"),
+		"URL of the Argo CD server to read the applications from instead of the Kubernetes API; the API token is read from ARGOCD_AUTH_TOKEN. Env: ARGOCD_SERVER")
+	flags.StringVar(&cluster.argoCdProjects, "argocd-projects", os.Getenv("ARGOCD_PROJECTS"),
+		"Comma separated Argo CD projects to read the applications of through the Argo CD server; by default all. Env: ARGOCD_PROJECTS")
+	flags.BoolVar(&cluster.argoCdInsecure, "argocd-insecure", os.Getenv("ARGOCD_INSECURE") == "true",
+		"Skip verifying the certificate of the Argo CD server. Env: ARGOCD_INSECURE")
+	flags.BoolVar(&cluster.flux, "flux", os.Getenv("FLUX") == "true",
+		"Read the Kustomizations and HelmReleases of Flux as applications instead of Argo CD applications. Env: FLUX")
+	flags.StringVar(&cluster.fixture, "fixture", os.Getenv("FIXTURE"),
+		"Snapshot file, or directory of snapshots replayed in order, to read the applications from instead of a cluster. Env: FIXTURE")
+	return cluster
+}
+
+// newFilter combines the rules of the filter config with the namespaces ignored by IGNORE_NAMESPACE.
+func (cluster *clusterFlags) newFilter() (*filter.Filter, error) {
+	var config filter.Config
+	if cluster.filterConfig != "" {
+		var err error
+		if config, err = filter.Load(cluster.filterConfig); err != nil {
+			return nil, err
+		}
+	}
+	config.Exclude = append(config.Exclude, filter.ExcludeNamespaces(getIgnoredNamespaces())...)
+	return filter.New(config)
+}
+
+// newGateway replays the fixture if one is configured, reads the applications through the Argo CD server if one is
+// configured, the Flux objects if enabled, otherwise the Argo CD applications through the Kubernetes API. The gateway
+// returns the applications matching the filter, which the caller builds once to show the same rules it applies.
+func (cluster *clusterFlags) newGateway(applicationFilter *filter.Filter) (app.ApplicationGateway, error) {
+	if cluster.err != nil {
+		return nil, cluster.err
+	}
+
+	if cluster.fixture != "" {
+		if cluster.argoCdServer != "" || cluster.flux || cluster.podStatus || cluster.events || cluster.discoverUrls {
+			return nil, errors.New("a fixture replaces the cluster, it can't be used with an Argo CD server, Flux, pod status, events or URL discovery")
+		}
+		fixtureGateway, err := gateway.NewFixtureGateway(cluster.fixture, applicationFilter)
+		if err != nil {
+			return nil, err
+		}
+		return fixtureGateway, nil
+	}
+
+	if cluster.argoCdServer != "" {
+		if cluster.flux {
+			return nil, errors.New("an Argo CD server can't be used with Flux")
+		}
+		if cluster.podStatus || cluster.events || cluster.discoverUrls {
+			return nil, errors.New("pod status, events and URL discovery need the Kubernetes API, they can't be used with an Argo CD server")
+		}
+		argoCdGateway, err := gateway.NewArgoCdGateway(&http.Client{Timeout: time.Minute}, gateway.ArgoCdOptions{
+			Server:   cluster.argoCdServer,
+			Token:    strings.TrimSpace(os.Getenv("ARGOCD_AUTH_TOKEN")),
+			Projects: parseList(cluster.argoCdProjects),
+			Insecure: cluster.argoCdInsecure,
+			Filter:   applicationFilter,
+		})
+		if err != nil {
+			// never return a nil gateway as non-nil interface
+			return nil, err
+		}
+		return argoCdGateway, nil
+	}
+
+	options := gateway.Options{
+		PodStatus:             cluster.podStatus,
+		Events:                cluster.events,
+		EventWindow:           cluster.eventWindow,
+		EventLimit:            cluster.eventLimit,
+		DiscoverUrls:          cluster.discoverUrls,
+		Filter:                applicationFilter,
+		ApplicationNamespaces: parseList(cluster.applicationNamespaces),
+		PageSize:              cluster.pageSize,
+		ToolNamespace:         cluster.toolNamespace,
+	}
+	if cluster.flux {
+		if len(options.ApplicationNamespaces) > 0 {
+			return nil, errors.New("application namespaces select Argo CD applications, they can't be used with Flux")
+		}
+		fluxGateway, err := gateway.NewFluxGateway(cluster.inCluster, cluster.kubeconfig, options)
+		if err != nil {
+			return nil, err
+		}
+		return fluxGateway, nil
+	}
+
+	kubernetesGateway, err := gateway.NewApplicationGateway(cluster.inCluster, cluster.kubeconfig, options)
+	if err != nil {
+		return nil, err
+	}
+	return kubernetesGateway, nil
+}
+
+func (cluster *clusterFlags) getApplications(ctx context.Context) (app.Applications, error) {
+	applicationFilter, err := cluster.newFilter()
+	if err != nil {
+		return app.Applications{}, err
+	}
+	applicationGateway, err := cluster.newGateway(applicationFilter)
+	if err != nil {
+		return app.Applications{}, err
+	}
+	return applicationGateway.GetApplications(ctx)
+}
+
+// signalContext returns a context that is cancelled on SIGINT or SIGTERM.
+func signalContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+}
+
+func newFlagSet(command string, output io.Writer) *flag.FlagSet {
+	flags := flag.NewFlagSet(command, flag.ContinueOnError)
+	flags.SetOutput(output)
+	return flags
+}
+
+// parseFlags returns the exit code to stop with if parsing failed or help was requested.
+func parseFlags(flags *flag.FlagSet, args []string) (int, bool) {
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOk, false
+		}
+		return exitError, false
+	}
+	return exitOk, true
+}
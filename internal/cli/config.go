@@ -0,0 +1,231 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"dashboard/internal/app"
+	"dashboard/internal/filter"
+	"dashboard/internal/forge"
+	"dashboard/internal/owner"
+	"dashboard/internal/version"
+	"dashboard/internal/web"
+)
+
+func getAppConfig(port int, syncInterval time.Duration, applicationFilter *filter.Filter, flags *flag.FlagSet) *app.ApplicationConfig {
+	return &app.ApplicationConfig{
+		Filters:         applicationFilter.String(),
+		EnvironmentName: getEnvironmentName(),
+		Port:            port,
+		SyncInterval:    syncInterval,
+		Build:           version.Get(),
+		Settings:        settingsOf(flags),
+	}
+}
+
+// settingsOf lists the flags with their values, which include the values from the environment. Credentials are only
+// read from the environment and never shown, passwords in URLs are redacted.
+func settingsOf(flags *flag.FlagSet) []app.Setting {
+	var settings []app.Setting
+	flags.VisitAll(func(option *flag.Flag) {
+		value := option.Value.String()
+		if parsed, err := url.Parse(value); err == nil && parsed.User != nil {
+			value = parsed.Redacted()
+		}
+		settings = append(settings, app.Setting{Name: option.Name, Value: value})
+	})
+	return settings
+}
+
+func getEnvironmentName() string {
+	envNameFromENV := strings.TrimSpace(os.Getenv("ENVIRONMENT_NAME"))
+
+	if envNameFromENV != "" {
+		return envNameFromENV
+	}
+	return "Unset"
+}
+
+// getIgnoredNamespaces returns the destination namespaces of IGNORE_NAMESPACE, which are excluded in addition to the
+// filter config.
+func getIgnoredNamespaces() []string {
+	ignoreNamespaceRaw := strings.TrimSpace(os.Getenv("IGNORE_NAMESPACE"))
+
+	if ignoreNamespaceRaw != "" {
+		return strings.Split(ignoreNamespaceRaw, ",")
+	}
+	return []string{}
+}
+
+// getDurationFromEnv returns the duration configured in the environment variable or the fallback if it is unset.
+func getDurationFromEnv(name string, fallback time.Duration) (time.Duration, error) {
+	raw := strings.TrimSpace(os.Getenv(name))
+	if raw == "" {
+		return fallback, nil
+	}
+
+	duration, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration in %s: %w", name, err)
+	}
+	return duration, nil
+}
+
+// getSyncInterval returns the interval of SYNC_INTERVAL, the default interval of the dashboard if it is unset.
+func getSyncInterval() (time.Duration, error) {
+	interval, err := getDurationFromEnv("SYNC_INTERVAL", app.DefaultSyncInterval)
+	if err == nil && interval <= 0 {
+		err = fmt.Errorf("invalid duration in SYNC_INTERVAL: %v is not positive", interval)
+	}
+	return interval, err
+}
+
+// getIntFromEnv returns the number configured in the environment variable or the fallback if it is unset.
+func getIntFromEnv(name string, fallback int) (int, error) {
+	raw := strings.TrimSpace(os.Getenv(name))
+	if raw == "" {
+		return fallback, nil
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number in %s: %w", name, err)
+	}
+	return value, nil
+}
+
+// getBranding overrides the default branding with the values set in the environment.
+func getBranding() (web.Branding, error) {
+	branding := web.DefaultBranding()
+
+	overrideFromEnv(&branding.Title, "BRANDING_TITLE")
+	overrideFromEnv(&branding.Name, "BRANDING_NAME")
+	overrideFromEnv(&branding.LogoUrl, "BRANDING_LOGO_URL")
+	overrideFromEnv(&branding.PrimaryColor, "BRANDING_PRIMARY_COLOR")
+	overrideFromEnv(&branding.BackgroundColor, "BRANDING_BACKGROUND_COLOR")
+	overrideFromEnv(&branding.HeaderBackgroundColor, "BRANDING_HEADER_BACKGROUND_COLOR")
+	overrideFromEnv(&branding.TextColor, "BRANDING_TEXT_COLOR")
+	overrideFromEnv(&branding.FooterText, "BRANDING_FOOTER_TEXT")
+	overrideFromEnv(&branding.Announcement, "BRANDING_ANNOUNCEMENT")
+
+	if raw, found := os.LookupEnv("BRANDING_HEADER_LINKS"); found {
+		links, err := parseLinks(raw)
+		if err != nil {
+			return branding, fmt.Errorf("invalid BRANDING_HEADER_LINKS: %w", err)
+		}
+		branding.HeaderLinks = links
+	}
+	if raw, found := os.LookupEnv("BRANDING_FOOTER_LINK"); found {
+		links, err := parseLinks(raw)
+		if err != nil || len(links) > 1 {
+			return branding, fmt.Errorf("invalid BRANDING_FOOTER_LINK, expected a single <name>=<url>")
+		}
+		branding.FooterLink = web.Link{}
+		if len(links) == 1 {
+			branding.FooterLink = links[0]
+		}
+	}
+	return branding, nil
+}
+
+func overrideFromEnv(value *string, name string) {
+	if raw := strings.TrimSpace(os.Getenv(name)); raw != "" {
+		*value = raw
+	}
+}
+
+// parseLinks parses a comma separated list of <name>=<url> pairs. An empty list removes all links.
+func parseLinks(raw string) ([]web.Link, error) {
+	links := []web.Link{}
+	for _, entry := range strings.Split(raw, ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		name, url, found := strings.Cut(entry, "=")
+		if !found || strings.TrimSpace(name) == "" || strings.TrimSpace(url) == "" {
+			return nil, fmt.Errorf("expected <name>=<url>, got %q", entry)
+		}
+		links = append(links, web.Link{Name: strings.TrimSpace(name), Url: strings.TrimSpace(url)})
+	}
+	return links, nil
+}
+
+// getForgeHosts parses FORGE_HOSTS, a comma separated list of <host>=<forge>, i.e. git.example.org=gitlab.
+func getForgeHosts() (map[string]forge.Kind, error) {
+	hosts := map[string]forge.Kind{}
+	for _, entry := range strings.Split(os.Getenv("FORGE_HOSTS"), ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		host, name, found := strings.Cut(entry, "=")
+		if !found || strings.TrimSpace(host) == "" {
+			return nil, fmt.Errorf("invalid FORGE_HOSTS entry %q, expected <host>=<forge>", entry)
+		}
+		kind, err := forge.ParseKind(name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid FORGE_HOSTS entry %q: %w", entry, err)
+		}
+		hosts[strings.TrimSpace(host)] = kind
+	}
+	return hosts, nil
+}
+
+// getForgeTokens parses FORGE_TOKENS, a comma separated list of <host>=<token> used to read commits from forge APIs.
+func getForgeTokens() (map[string]string, error) {
+	tokens := map[string]string{}
+	for _, entry := range strings.Split(os.Getenv("FORGE_TOKENS"), ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		host, token, found := strings.Cut(entry, "=")
+		if !found || strings.TrimSpace(host) == "" || strings.TrimSpace(token) == "" {
+			// never echo the entry, it contains a secret
+			return nil, fmt.Errorf("invalid FORGE_TOKENS entry, expected <host>=<token>")
+		}
+		tokens[strings.TrimSpace(host)] = strings.TrimSpace(token)
+	}
+	return tokens, nil
+}
+
+// getOwnerKeys returns the keys of OWNER_KEYS, the default keys if it is unset. Setting it empty disables the owner.
+func getOwnerKeys() string {
+	if raw, found := os.LookupEnv("OWNER_KEYS"); found {
+		return raw
+	}
+	return strings.Join(owner.DefaultKeys, ",")
+}
+
+// parseList parses a comma separated list, i.e. of label keys or namespaces, skipping empty entries.
+func parseList(raw string) []string {
+	values := []string{}
+	for _, value := range strings.Split(raw, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
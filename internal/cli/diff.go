@@ -0,0 +1,169 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"dashboard/internal/app"
+)
+
+type applicationDiff struct {
+	key     string
+	added   bool
+	removed bool
+	changes []string
+}
+
+func diff(args []string) int {
+	flags := newFlagSet("diff", os.Stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: dashboard diff <old snapshot> <new snapshot>")
+	}
+	if code, ok := parseFlags(flags, args); !ok {
+		return code
+	}
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return exitError
+	}
+
+	oldApplications, err := readSnapshot(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	newApplications, err := readSnapshot(flags.Arg(1))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	diffs := diffApplications(oldApplications, newApplications)
+	printDiffs(os.Stdout, diffs)
+
+	if len(diffs) > 0 {
+		return exitFindings
+	}
+	return exitOk
+}
+
+func diffApplications(oldApplications app.Applications, newApplications app.Applications) []applicationDiff {
+	oldItems := itemsByKey(oldApplications)
+	newItems := itemsByKey(newApplications)
+
+	var diffs []applicationDiff
+	for key, oldItem := range oldItems {
+		newItem, found := newItems[key]
+		if !found {
+			diffs = append(diffs, applicationDiff{key: key, removed: true})
+			continue
+		}
+		if changes := diffItem(oldItem, newItem); len(changes) > 0 {
+			diffs = append(diffs, applicationDiff{key: key, changes: changes})
+		}
+	}
+	for key := range newItems {
+		if _, found := oldItems[key]; !found {
+			diffs = append(diffs, applicationDiff{key: key, added: true})
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].key < diffs[j].key
+	})
+	return diffs
+}
+
+func diffItem(oldItem app.Item, newItem app.Item) []string {
+	var changes []string
+	changes = appendChange(changes, "health", oldItem.Status.Health.Status, newItem.Status.Health.Status)
+	changes = appendChange(changes, "sync", oldItem.Status.Sync.Status, newItem.Status.Sync.Status)
+	changes = appendChange(changes, "target revision", oldItem.Spec.Source.TargetRevision, newItem.Spec.Source.TargetRevision)
+	changes = appendChange(changes, "deployed revision", deployedRevision(oldItem), deployedRevision(newItem))
+
+	oldImages := asSet(oldItem.Status.Summary.Images)
+	newImages := asSet(newItem.Status.Summary.Images)
+	for _, image := range newItem.Status.Summary.Images {
+		if !oldImages[image] {
+			changes = append(changes, "image added: "+image)
+		}
+	}
+	for _, image := range oldItem.Status.Summary.Images {
+		if !newImages[image] {
+			changes = append(changes, "image removed: "+image)
+		}
+	}
+	return changes
+}
+
+func appendChange(changes []string, field string, oldValue string, newValue string) []string {
+	if oldValue == newValue {
+		return changes
+	}
+	return append(changes, fmt.Sprintf("%s: %s -> %s", field, valueOrNone(oldValue), valueOrNone(newValue)))
+}
+
+func deployedRevision(item app.Item) string {
+	latest, _ := item.LatestDeployment()
+	return latest.Revision
+}
+
+func printDiffs(out io.Writer, diffs []applicationDiff) {
+	for _, d := range diffs {
+		switch {
+		case d.added:
+			fmt.Fprintf(out, "+ %s\n", d.key)
+		case d.removed:
+			fmt.Fprintf(out, "- %s\n", d.key)
+		default:
+			fmt.Fprintf(out, "~ %s\n", d.key)
+			for _, change := range d.changes {
+				fmt.Fprintf(out, "    %s\n", change)
+			}
+		}
+	}
+}
+
+func itemsByKey(applications app.Applications) map[string]app.Item {
+	result := make(map[string]app.Item, len(applications.Items))
+	for _, item := range applications.Items {
+		result[applicationKey(item)] = item
+	}
+	return result
+}
+
+func asSet(values []string) map[string]bool {
+	result := make(map[string]bool, len(values))
+	for _, value := range values {
+		result[value] = true
+	}
+	return result
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
@@ -0,0 +1,92 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package cli
+
+import (
+	"dashboard/internal/app"
+	"reflect"
+	"testing"
+)
+
+func TestShouldReportNoDiffForEqualSnapshots(t *testing.T) {
+	applications := app.Applications{Items: []app.Item{applicationItem("argocd", "irs", "Healthy", "Synced")}}
+
+	diffs := diffApplications(applications, applications)
+
+	if len(diffs) != 0 {
+		t.Errorf("Expected no differences for equal snapshots! Got: %v", diffs)
+	}
+}
+
+func TestShouldReportAddedAndRemovedApplications(t *testing.T) {
+	oldApplications := app.Applications{Items: []app.Item{applicationItem("argocd", "removed-app", "Healthy", "Synced")}}
+	newApplications := app.Applications{Items: []app.Item{applicationItem("argocd", "added-app", "Healthy", "Synced")}}
+
+	diffs := diffApplications(oldApplications, newApplications)
+
+	expected := []applicationDiff{
+		{key: "argocd/added-app", added: true},
+		{key: "argocd/removed-app", removed: true},
+	}
+	if !reflect.DeepEqual(diffs, expected) {
+		t.Errorf("Added and removed applications not reported correctly! \nexpected: %v \nGot: %v", expected, diffs)
+	}
+}
+
+func TestShouldReportChangedStatusRevisionAndImages(t *testing.T) {
+	oldItem := applicationItem("argocd", "irs", "Healthy", "Synced")
+	oldItem.Spec.Source.TargetRevision = "1.0.0"
+	oldItem.Status.Summary.Images = []string{"tractusx/irs:1.0.0", "postgres:15"}
+	newItem := applicationItem("argocd", "irs", "Degraded", "Synced")
+	newItem.Spec.Source.TargetRevision = "1.1.0"
+	newItem.Status.Summary.Images = []string{"tractusx/irs:1.1.0", "postgres:15"}
+
+	diffs := diffApplications(app.Applications{Items: []app.Item{oldItem}}, app.Applications{Items: []app.Item{newItem}})
+
+	expected := []applicationDiff{{key: "argocd/irs", changes: []string{
+		"health: Healthy -> Degraded",
+		"target revision: 1.0.0 -> 1.1.0",
+		"image added: tractusx/irs:1.1.0",
+		"image removed: tractusx/irs:1.0.0",
+	}}}
+	if !reflect.DeepEqual(diffs, expected) {
+		t.Errorf("Changed application not reported correctly! \nexpected: %v \nGot: %v", expected, diffs)
+	}
+}
+
+func TestShouldUseNewestHistoryEntryAsDeployedRevision(t *testing.T) {
+	item := applicationItem("argocd", "irs", "Healthy", "Synced")
+	item.Status.History = []app.History{{Id: 2, Revision: "second"}, {Id: 3, Revision: "third"}, {Id: 1, Revision: "first"}}
+
+	revision := deployedRevision(item)
+
+	if revision != "third" {
+		t.Errorf("Deployed revision not taken from newest history entry! \nexpected: third \nGot: %s", revision)
+	}
+}
+
+func applicationItem(namespace string, name string, health string, sync string) app.Item {
+	item := app.Item{}
+	item.Metadata.Namespace = namespace
+	item.Metadata.Name = name
+	item.Status.Health.Status = health
+	item.Status.Sync.Status = sync
+	return item
+}
@@ -0,0 +1,173 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"dashboard/internal/app"
+	"dashboard/internal/changes"
+	"dashboard/internal/charts"
+	"dashboard/internal/forge"
+	"dashboard/internal/owner"
+	"dashboard/internal/probe"
+	"dashboard/internal/release"
+	"dashboard/internal/web"
+)
+
+func serve(args []string) int {
+	flags := newFlagSet("serve", os.Stderr)
+	cluster := registerClusterFlags(flags)
+	port := flags.Int("port", 8080, "Port the dashboard is served on.")
+	defaultSyncInterval, err := getSyncInterval()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	syncInterval := flags.Duration("sync-interval", defaultSyncInterval,
+		"How often the applications are read; with a fixture directory, how often the next snapshot is replayed. Env: SYNC_INTERVAL")
+	shutdownTimeout := flags.Duration("shutdown-timeout", 10*time.Second, "Time to wait for in-flight requests on shutdown.")
+	defaultMaxStaleness, err := getDurationFromEnv("READINESS_MAX_STALENESS", 15*time.Minute)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	maxStaleness := flags.Duration("readiness-max-staleness", defaultMaxStaleness,
+		"Maximum age of the last successful sync before /readyz reports not ready; 0 disables the check. Env: READINESS_MAX_STALENESS")
+	overrideDir := flags.String("web-override-dir", os.Getenv("WEB_OVERRIDE_DIR"),
+		"Directory with files replacing the embedded web assets and templates, e.g. for custom theming. Env: WEB_OVERRIDE_DIR")
+	defaultChartIndexRefresh, err := getDurationFromEnv("CHART_INDEX_REFRESH", time.Hour)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	chartIndexRefresh := flags.Duration("chart-index-refresh", defaultChartIndexRefresh,
+		"How long the index.yaml of Helm repositories is cached to resolve chart versions; 0 disables resolving. Env: CHART_INDEX_REFRESH")
+	gitMirrorDir := flags.String("git-mirror-dir", os.Getenv("GIT_MIRROR_DIR"),
+		"Directory with git mirrors (<host>/<path>.git) to list the commits between deployments. Env: GIT_MIRROR_DIR")
+	releaseManifestPath := flags.String("release-manifest", os.Getenv("RELEASE_MANIFEST"),
+		"YAML or JSON file listing the product versions of the release the environment should be on. Env: RELEASE_MANIFEST")
+	defaultProbeInterval, err := getDurationFromEnv("PROBE_INTERVAL", 0)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	probeInterval := flags.Duration("probe-interval", defaultProbeInterval,
+		"How often the external URLs are requested to check they are reachable and their certificates are valid; 0 disables probing. Env: PROBE_INTERVAL")
+	defaultProbeTimeout, err := getDurationFromEnv("PROBE_TIMEOUT", 10*time.Second)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	probeTimeout := flags.Duration("probe-timeout", defaultProbeTimeout, "Time to wait for an external URL to answer. Env: PROBE_TIMEOUT")
+	ownerKeys := flags.String("owner-keys", getOwnerKeys(),
+		"Comma separated labels and annotations of the applications shown as their owner, labels first; empty disables the owner. Env: OWNER_KEYS")
+	if code, ok := parseFlags(flags, args); !ok {
+		return code
+	}
+	if *syncInterval <= 0 {
+		fmt.Fprintf(os.Stderr, "Invalid sync interval %v, it has to be positive\n", *syncInterval)
+		return exitError
+	}
+
+	branding, err := getBranding()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	forgeHosts, err := getForgeHosts()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	forgeTokens, err := getForgeTokens()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	var releaseManifest *release.Manifest
+	if *releaseManifestPath != "" {
+		if releaseManifest, err = release.Load(*releaseManifestPath); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return exitError
+		}
+	}
+
+	applicationFilter, err := cluster.newFilter()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid filter: %v\n", err)
+		return exitError
+	}
+	applicationGateway, err := cluster.newGateway(applicationFilter)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Could not connect to the cluster: %v\n", err)
+		return exitError
+	}
+	webserver, err := web.NewWebserver(web.Config{
+		OverrideDir:           *overrideDir,
+		ShutdownTimeout:       *shutdownTimeout,
+		ReadinessMaxStaleness: *maxStaleness,
+		Branding:              branding,
+		ForgeHosts:            forgeHosts,
+		ReleaseManifest:       releaseManifest,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Could not create webserver: %v\n", err)
+		return exitError
+	}
+
+	ctx, stop := signalContext()
+	defer stop()
+
+	var enrichers []app.ApplicationEnricher
+	if keys := parseList(*ownerKeys); len(keys) > 0 {
+		enrichers = append(enrichers, owner.NewEnricher(keys))
+	}
+	if *chartIndexRefresh > 0 {
+		enrichers = append(enrichers, charts.NewEnricher(&http.Client{Timeout: 30 * time.Second}, *chartIndexRefresh))
+	}
+	if *gitMirrorDir != "" || len(forgeTokens) > 0 {
+		var sources []changes.CommitSource
+		if *gitMirrorDir != "" {
+			sources = append(sources, changes.NewMirrorSource(*gitMirrorDir))
+		}
+		if len(forgeTokens) > 0 {
+			sources = append(sources, changes.NewApiSource(&http.Client{Timeout: 10 * time.Second}, forgeTokens))
+		}
+		changesEnricher := changes.NewEnricher(forge.NewResolver(forgeHosts), sources...)
+		go changesEnricher.Run(ctx)
+		enrichers = append(enrichers, changesEnricher)
+	}
+	if *probeInterval > 0 {
+		prober := probe.NewProber(&http.Client{Timeout: *probeTimeout}, *probeInterval)
+		go prober.Run(ctx)
+		enrichers = append(enrichers, prober)
+	}
+
+	if err := app.NewDashboard(applicationGateway, webserver, getAppConfig(*port, *syncInterval, applicationFilter, flags), enrichers...).Run(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Dashboard stopped: %v\n", err)
+		return exitError
+	}
+	return exitOk
+}
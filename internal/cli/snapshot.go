@@ -0,0 +1,101 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"dashboard/internal/app"
+	"sigs.k8s.io/yaml"
+)
+
+func snapshot(args []string) int {
+	flags := newFlagSet("snapshot", os.Stderr)
+	cluster := registerClusterFlags(flags)
+	format := flags.String("format", "json", "Output format of the snapshot, either json or yaml.")
+	output := flags.String("output", "", "File to write the snapshot to. Defaults to stdout.")
+	if code, ok := parseFlags(flags, args); !ok {
+		return code
+	}
+
+	if *format != "json" && *format != "yaml" {
+		fmt.Fprintf(os.Stderr, "Unsupported format %q, use json or yaml\n", *format)
+		return exitError
+	}
+
+	ctx, stop := signalContext()
+	defer stop()
+
+	applications, err := cluster.getApplications(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Could not get applications: %v\n", err)
+		return exitError
+	}
+
+	data, err := marshalSnapshot(applications, *format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Could not create snapshot: %v\n", err)
+		return exitError
+	}
+
+	if *output == "" {
+		_, err = os.Stdout.Write(data)
+	} else {
+		err = os.WriteFile(*output, data, 0644)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Could not write snapshot: %v\n", err)
+		return exitError
+	}
+	return exitOk
+}
+
+func marshalSnapshot(applications app.Applications, format string) ([]byte, error) {
+	if format == "yaml" {
+		return yaml.Marshal(applications)
+	}
+
+	data, err := json.MarshalIndent(applications, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// readSnapshot reads a snapshot written by the snapshot command. Since JSON is valid YAML, both formats are
+// accepted, as well as the output of "kubectl get applications.argoproj.io -o json".
+func readSnapshot(path string) (app.Applications, error) {
+	var applications app.Applications
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return applications, err
+	}
+	if err := yaml.Unmarshal(data, &applications); err != nil {
+		return applications, fmt.Errorf("could not parse snapshot %s: %w", path, err)
+	}
+	return applications, nil
+}
+
+func applicationKey(item app.Item) string {
+	return item.Metadata.Namespace + "/" + item.Metadata.Name
+}
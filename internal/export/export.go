@@ -0,0 +1,165 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+// Package export writes the application inventory in formats used by release management.
+package export
+
+import (
+	"strings"
+
+	"dashboard/internal/app"
+)
+
+// Row is the flattened view of an application that all export formats share.
+type Row struct {
+	Name      string
+	Namespace string
+	Project   string
+	// Owner lists the owner fields as <key>: <value>
+	Owner        []string
+	Health       string
+	Sync         string
+	Revision     string
+	LastDeployed string
+	Images       []string
+	Postgresql   string
+	ExternalUrls []string
+}
+
+var columns = []string{
+	"Name", "Namespace", "Project", "Owner", "Health", "Sync", "Revision", "Last deployed", "Images", "PostgreSQL", "External URLs",
+}
+
+// Filter selects the applications to export. Empty fields match everything.
+type Filter struct {
+	// Query matches case-insensitively on the values the table of the dashboard searches, like the name, owner,
+	// images, repository and versions, so exports contain the rows shown after searching
+	Query     string
+	Namespace string
+	Health    string
+	Sync      string
+	// Owner matches case-insensitively on the owner values, i.e. a team
+	Owner string
+}
+
+func (filter Filter) matches(item app.Item) bool {
+	if filter.Namespace != "" && item.Spec.Destination.Namespace != filter.Namespace {
+		return false
+	}
+	if filter.Health != "" && !strings.EqualFold(item.Status.Health.Status, filter.Health) {
+		return false
+	}
+	if filter.Sync != "" && !strings.EqualFold(item.Status.Sync.Status, filter.Sync) {
+		return false
+	}
+	if filter.Owner != "" && !containsAny(ownerValues(item), filter.Owner) {
+		return false
+	}
+	if filter.Query == "" {
+		return true
+	}
+
+	return containsAny(searchableValues(item), filter.Query)
+}
+
+// searchableValues are the values of the application rendered in the table of the dashboard.
+func searchableValues(item app.Item) []string {
+	values := []string{
+		item.Metadata.Name, item.Spec.Destination.Namespace, item.Spec.Project, item.Status.Health.Status,
+		item.Status.Sync.Status, item.Spec.Source.RepoUrl, item.Spec.Source.Path, item.Spec.Source.Chart,
+		item.Spec.Source.TargetRevision, item.DeployedVersion(), item.Status.Summary.PostgresqlImage,
+	}
+	values = append(values, ownerValues(item)...)
+	values = append(values, item.Status.Summary.Images...)
+	values = append(values, item.Status.Summary.ExternalUrls...)
+	for _, entry := range item.Status.History {
+		values = append(values, entry.Version())
+	}
+	return values
+}
+
+func ownerValues(item app.Item) []string {
+	values := make([]string, 0, len(item.Owner))
+	for _, field := range item.Owner {
+		values = append(values, field.Value)
+	}
+	return values
+}
+
+// containsAny reports whether any of the values contains the query, ignoring case.
+func containsAny(values []string, query string) bool {
+	query = strings.ToLower(query)
+	for _, value := range values {
+		if strings.Contains(strings.ToLower(value), query) {
+			return true
+		}
+	}
+	return false
+}
+
+// Rows returns the rows of all applications matching the filter.
+func Rows(applications app.Applications, filter Filter) []Row {
+	rows := make([]Row, 0, len(applications.Items))
+	for _, item := range applications.Items {
+		if !filter.matches(item) {
+			continue
+		}
+		rows = append(rows, rowOf(item))
+	}
+	return rows
+}
+
+func rowOf(item app.Item) Row {
+	row := Row{
+		Name:         item.Metadata.Name,
+		Namespace:    item.Spec.Destination.Namespace,
+		Project:      item.Spec.Project,
+		Health:       item.Status.Health.Status,
+		Sync:         item.Status.Sync.Status,
+		Revision:     item.Spec.Source.TargetRevision,
+		Images:       item.Status.Summary.Images,
+		Postgresql:   item.Status.Summary.PostgresqlImage,
+		ExternalUrls: item.Status.Summary.ExternalUrls,
+	}
+	for _, field := range item.Owner {
+		row.Owner = append(row.Owner, field.Key+": "+field.Value)
+	}
+	if latest, found := item.LatestDeployment(); found {
+		row.Revision = latest.Revision
+		row.LastDeployed = latest.DeployedAt
+	}
+	return row
+}
+
+// values returns the cells of the row in the order of the columns, joining lists with the separator.
+func (row Row) values(separator string) []string {
+	return []string{
+		row.Name,
+		row.Namespace,
+		row.Project,
+		strings.Join(row.Owner, separator),
+		row.Health,
+		row.Sync,
+		row.Revision,
+		row.LastDeployed,
+		strings.Join(row.Images, separator),
+		row.Postgresql,
+		strings.Join(row.ExternalUrls, separator),
+	}
+}
@@ -0,0 +1,202 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"dashboard/internal/app"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestShouldFlattenApplicationIntoRow(t *testing.T) {
+	rows := Rows(app.Applications{Items: []app.Item{givenApplication("irs", "product-irs")}}, Filter{})
+
+	expected := Row{
+		Name:         "irs",
+		Namespace:    "product-irs",
+		Project:      "project-irs",
+		Owner:        []string{"team: traceability", "slack-channel: #irs"},
+		Health:       "Healthy",
+		Sync:         "Synced",
+		Revision:     "2.0.0",
+		LastDeployed: "2022-09-18T07:26:00Z",
+		Images:       []string{"tractusx/irs:2.0.0", "bitnami/postgresql:15"},
+		Postgresql:   "15",
+		ExternalUrls: []string{"https://irs.example.org"},
+	}
+	if len(rows) != 1 || !equalRows(rows[0], expected) {
+		t.Errorf("Application not flattened correctly! \nexpected: %v \nGot: %v", expected, rows)
+	}
+}
+
+func TestShouldFilterRows(t *testing.T) {
+	degraded := givenApplication("edc", "product-edc")
+	degraded.Status.Health.Status = "Degraded"
+	degraded.Spec.Project = "project-edc"
+	degraded.Owner = []app.OwnerField{{Key: "team", Value: "connector"}}
+	degraded.Status.Summary.Images = []string{"tractusx/edc-controlplane:0.6.0"}
+	degraded.Spec.Source.RepoUrl = "https://eclipse-tractusx.github.io/charts/dev"
+	applications := app.Applications{Items: []app.Item{givenApplication("irs", "product-irs"), degraded}}
+
+	tests := map[string]struct {
+		filter   Filter
+		expected []string
+	}{
+		"no filter":       {Filter{}, []string{"irs", "edc"}},
+		"query":           {Filter{Query: "EDC"}, []string{"edc"}},
+		"query project":   {Filter{Query: "project-irs"}, []string{"irs"}},
+		"query owner":     {Filter{Query: "TRACEABILITY"}, []string{"irs"}},
+		"query image":     {Filter{Query: "irs:2.0.0"}, []string{"irs"}},
+		"query image tag": {Filter{Query: "controlplane:0.6"}, []string{"edc"}},
+		"query version":   {Filter{Query: "1.0.0"}, []string{"irs", "edc"}},
+		"query health":    {Filter{Query: "degraded"}, []string{"edc"}},
+		"query repo":      {Filter{Query: "charts/dev"}, []string{"edc"}},
+		"owner":           {Filter{Owner: "connector"}, []string{"edc"}},
+		"owner not query": {Filter{Owner: "product-irs"}, []string{}},
+		"namespace":       {Filter{Namespace: "product-irs"}, []string{"irs"}},
+		"health":          {Filter{Health: "degraded"}, []string{"edc"}},
+		"sync":            {Filter{Sync: "OutOfSync"}, []string{}},
+	}
+	for name, test := range tests {
+		var names []string
+		for _, row := range Rows(applications, test.filter) {
+			names = append(names, row.Name)
+		}
+		if strings.Join(names, ",") != strings.Join(test.expected, ",") {
+			t.Errorf("%s: wrong rows filtered! \nexpected: %v \nGot: %v", name, test.expected, names)
+		}
+	}
+}
+
+func TestShouldWriteCsv(t *testing.T) {
+	var out bytes.Buffer
+
+	err := WriteCsv(&out, Rows(app.Applications{Items: []app.Item{givenApplication("irs", "product-irs")}}, Filter{}))
+
+	expected := "Name,Namespace,Project,Owner,Health,Sync,Revision,Last deployed,Images,PostgreSQL,External URLs\n" +
+		"irs,product-irs,project-irs,\"team: traceability\nslack-channel: #irs\",Healthy,Synced,2.0.0,2022-09-18T07:26:00Z,\"tractusx/irs:2.0.0\nbitnami/postgresql:15\",15,https://irs.example.org\n"
+	if err != nil || out.String() != expected {
+		t.Errorf("CSV not written correctly! \nexpected: %s \nGot: %s", expected, out.String())
+	}
+}
+
+func TestShouldWriteMarkdownReport(t *testing.T) {
+	var out bytes.Buffer
+	application := givenApplication("irs", "product-irs")
+	application.Spec.Project = "a|b"
+
+	err := WriteMarkdown(&out, "Release 24.03", Rows(app.Applications{Items: []app.Item{application}}, Filter{}))
+
+	expected := "# Release 24.03\n\n" +
+		"| Name | Namespace | Project | Owner | Health | Sync | Revision | Last deployed | Images | PostgreSQL | External URLs |\n" +
+		"| --- | --- | --- | --- | --- | --- | --- | --- | --- | --- | --- |\n" +
+		"| irs | product-irs | a\\|b | team: traceability<br>slack-channel: #irs | Healthy | Synced | 2.0.0 | 2022-09-18T07:26:00Z | tractusx/irs:2.0.0<br>bitnami/postgresql:15 | 15 | https://irs.example.org |\n"
+	if err != nil || out.String() != expected {
+		t.Errorf("Markdown not written correctly! \nexpected: %s \nGot: %s", expected, out.String())
+	}
+}
+
+func TestShouldWriteAsciiDocReport(t *testing.T) {
+	var out bytes.Buffer
+
+	err := WriteAsciiDoc(&out, "Release 24.03", Rows(app.Applications{Items: []app.Item{givenApplication("irs", "product-irs")}}, Filter{}))
+
+	for _, expected := range []string{
+		"= Release 24.03\n",
+		"[options=\"header\",cols=\"11*a\"]\n|===\n|Name |Namespace |",
+		"\n|irs\n|product-irs\n",
+		"|tractusx/irs:2.0.0 +\nbitnami/postgresql:15\n",
+		"|===\n",
+	} {
+		if err != nil || !strings.Contains(out.String(), expected) {
+			t.Errorf("AsciiDoc does not contain %q! \nGot: %s", expected, out.String())
+		}
+	}
+}
+
+func TestShouldWriteXlsxWorkbook(t *testing.T) {
+	var out bytes.Buffer
+	application := givenApplication("irs & co", "product-irs")
+
+	if err := WriteXlsx(&out, Rows(app.Applications{Items: []app.Item{application}}, Filter{})); err != nil {
+		t.Fatal(err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(out.Bytes()), int64(out.Len()))
+	if err != nil {
+		t.Fatalf("Workbook is not a zip archive: %v", err)
+	}
+	sheet := readZipFile(archive, "xl/worksheets/sheet1.xml", t)
+	for _, expected := range []string{
+		`<c r="A1" t="inlineStr"><is><t xml:space="preserve">Name</t></is></c>`,
+		`<c r="A2" t="inlineStr"><is><t xml:space="preserve">irs &amp; co</t></is></c>`,
+		`<c r="K2" t="inlineStr"><is><t xml:space="preserve">https://irs.example.org</t></is></c>`,
+	} {
+		if !strings.Contains(sheet, expected) {
+			t.Errorf("Sheet does not contain %q! \nGot: %s", expected, sheet)
+		}
+	}
+	readZipFile(archive, "[Content_Types].xml", t)
+	readZipFile(archive, "xl/workbook.xml", t)
+}
+
+func TestShouldNameSpreadsheetColumns(t *testing.T) {
+	for index, expected := range map[int]string{0: "A", 9: "J", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"} {
+		if name := xlsxColumnName(index); name != expected {
+			t.Errorf("Wrong column name for index %d! \nexpected: %s \nGot: %s", index, expected, name)
+		}
+	}
+}
+
+func givenApplication(name string, namespace string) app.Item {
+	item := app.Item{}
+	item.Metadata.Name = name
+	item.Spec.Destination.Namespace = namespace
+	item.Spec.Project = "project-irs"
+	item.Spec.Source.TargetRevision = "2.*"
+	item.Status.Health.Status = "Healthy"
+	item.Status.Sync.Status = "Synced"
+	item.Status.History = []app.History{
+		{Id: 1, Revision: "1.0.0", DeployedAt: "2022-09-17T07:26:00Z"},
+		{Id: 2, Revision: "2.0.0", DeployedAt: "2022-09-18T07:26:00Z"},
+	}
+	item.Status.Summary.Images = []string{"tractusx/irs:2.0.0", "bitnami/postgresql:15"}
+	item.Status.Summary.PostgresqlImage = "15"
+	item.Status.Summary.ExternalUrls = []string{"https://irs.example.org"}
+	item.Owner = []app.OwnerField{{Key: "team", Value: "traceability"}, {Key: "slack-channel", Value: "#irs"}}
+	return item
+}
+
+func equalRows(actual Row, expected Row) bool {
+	return strings.Join(actual.values(","), "|") == strings.Join(expected.values(","), "|")
+}
+
+func readZipFile(archive *zip.Reader, name string, t *testing.T) string {
+	file, err := archive.Open(name)
+	if err != nil {
+		t.Fatalf("Workbook does not contain %s: %v", name, err)
+	}
+	defer file.Close()
+	content, _ := io.ReadAll(file)
+	return string(content)
+}
@@ -0,0 +1,72 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteMarkdown writes the rows as a Markdown table, suitable for pasting into release notes.
+func WriteMarkdown(w io.Writer, title string, rows []Row) error {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "# %s\n\n", title)
+	builder.WriteString("| " + strings.Join(columns, " | ") + " |\n")
+	builder.WriteString(strings.Repeat("| --- ", len(columns)) + "|\n")
+	for _, row := range rows {
+		values := row.values("<br>")
+		for i, value := range values {
+			values[i] = escapeMarkdown(value)
+		}
+		builder.WriteString("| " + strings.Join(values, " | ") + " |\n")
+	}
+
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
+
+// WriteAsciiDoc writes the rows as an AsciiDoc table, suitable for pasting into release notes.
+func WriteAsciiDoc(w io.Writer, title string, rows []Row) error {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "= %s\n\n", title)
+	fmt.Fprintf(&builder, "[options=\"header\",cols=\"%d*a\"]\n|===\n", len(columns))
+	builder.WriteString("|" + strings.Join(columns, " |") + "\n")
+	for _, row := range rows {
+		builder.WriteString("\n")
+		for _, value := range row.values(" +\n") {
+			builder.WriteString("|" + escapeAsciiDoc(value) + "\n")
+		}
+	}
+	builder.WriteString("|===\n")
+
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
+
+func escapeMarkdown(value string) string {
+	return strings.ReplaceAll(value, "|", "\\|")
+}
+
+func escapeAsciiDoc(value string) string {
+	return strings.ReplaceAll(value, "|", "\\|")
+}
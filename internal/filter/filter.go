@@ -0,0 +1,230 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+// Package filter selects the applications shown by the dashboard with include and exclude rules.
+package filter
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/yaml"
+
+	"dashboard/internal/app"
+)
+
+// Config lists the rules of a filter. An application is shown if it matches any include rule, or there are none,
+// and no exclude rule.
+type Config struct {
+	Include []Rule `json:"include,omitempty"`
+	Exclude []Rule `json:"exclude,omitempty"`
+}
+
+// Rule matches applications meeting all of its conditions.
+type Rule struct {
+	// Selector is a Kubernetes label selector on the labels of the Application, i.e. "team in (edc,portal)"
+	Selector string `json:"selector,omitempty"`
+	// Name and Namespace are globs like product-* or regular expressions between slashes like /^product-(edc|irs)$/.
+	// Namespace matches the destination namespace.
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	// Project is the Argo CD project of the application
+	Project string `json:"project,omitempty"`
+	// Server is the destination server, i.e. https://kubernetes.default.svc
+	Server string `json:"server,omitempty"`
+}
+
+// Filter is the compiled form of a Config. A nil filter matches all applications.
+type Filter struct {
+	config  Config
+	include []compiledRule
+	exclude []compiledRule
+}
+
+type compiledRule struct {
+	selector  labels.Selector
+	name      func(string) bool
+	namespace func(string) bool
+	project   string
+	server    string
+}
+
+// Load reads the filter config from a YAML or JSON file.
+func Load(path string) (Config, error) {
+	var config Config
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return config, fmt.Errorf("could not read filter config: %w", err)
+	}
+	if err := yaml.UnmarshalStrict(raw, &config); err != nil {
+		return config, fmt.Errorf("invalid filter config %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// ExcludeNamespaces returns the rules excluding the destination namespaces, as configured with IGNORE_NAMESPACE.
+func ExcludeNamespaces(namespaces []string) []Rule {
+	var rules []Rule
+	for _, namespace := range namespaces {
+		// names of namespaces contain no special characters of globs, so they match literally
+		if namespace = strings.TrimSpace(namespace); namespace != "" {
+			rules = append(rules, Rule{Namespace: namespace})
+		}
+	}
+	return rules
+}
+
+// New compiles the rules of the config, returning nil if there are none.
+func New(config Config) (*Filter, error) {
+	if len(config.Include) == 0 && len(config.Exclude) == 0 {
+		return nil, nil
+	}
+
+	filter := &Filter{config: config}
+	var err error
+	if filter.include, err = compileRules("include", config.Include); err != nil {
+		return nil, err
+	}
+	if filter.exclude, err = compileRules("exclude", config.Exclude); err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+func compileRules(kind string, rules []Rule) ([]compiledRule, error) {
+	compiled := make([]compiledRule, 0, len(rules))
+	for i, rule := range rules {
+		if rule == (Rule{}) {
+			return nil, fmt.Errorf("invalid %s rule %d: the rule has no conditions", kind, i+1)
+		}
+
+		result := compiledRule{project: rule.Project, server: rule.Server}
+		var err error
+		if result.selector, err = labels.Parse(rule.Selector); err != nil {
+			return nil, fmt.Errorf("invalid %s rule %d: selector: %w", kind, i+1, err)
+		}
+		if result.name, err = compilePattern(rule.Name); err != nil {
+			return nil, fmt.Errorf("invalid %s rule %d: name: %w", kind, i+1, err)
+		}
+		if result.namespace, err = compilePattern(rule.Namespace); err != nil {
+			return nil, fmt.Errorf("invalid %s rule %d: namespace: %w", kind, i+1, err)
+		}
+		compiled = append(compiled, result)
+	}
+	return compiled, nil
+}
+
+// compilePattern compiles a regular expression between slashes or a glob. An empty pattern matches everything.
+func compilePattern(pattern string) (func(string) bool, error) {
+	if pattern == "" {
+		return func(string) bool { return true }, nil
+	}
+	if len(pattern) > 1 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		expression, err := regexp.Compile(pattern[1 : len(pattern)-1])
+		if err != nil {
+			return nil, err
+		}
+		return expression.MatchString, nil
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid glob %q: %w", pattern, err)
+	}
+	return func(value string) bool {
+		matched, _ := path.Match(pattern, value)
+		return matched
+	}, nil
+}
+
+func (rule compiledRule) matches(item app.Item) bool {
+	return rule.selector.Matches(labels.Set(item.Metadata.Labels)) &&
+		rule.name(item.Metadata.Name) &&
+		rule.namespace(item.Spec.Destination.Namespace) &&
+		(rule.project == "" || rule.project == item.Spec.Project) &&
+		(rule.server == "" || rule.server == item.Spec.Destination.Server)
+}
+
+// Matches reports whether the application is shown.
+func (filter *Filter) Matches(item app.Item) bool {
+	if filter == nil {
+		return true
+	}
+	for _, rule := range filter.exclude {
+		if rule.matches(item) {
+			return false
+		}
+	}
+	if len(filter.include) == 0 {
+		return true
+	}
+	for _, rule := range filter.include {
+		if rule.matches(item) {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply removes the applications not matching the filter.
+func (filter *Filter) Apply(applications *app.Applications) {
+	if filter == nil {
+		return
+	}
+	items := make([]app.Item, 0, len(applications.Items))
+	for _, item := range applications.Items {
+		if filter.Matches(item) {
+			items = append(items, item)
+		}
+	}
+	applications.Items = items
+}
+
+// String describes the rules of the filter for the help of the dashboard.
+func (filter *Filter) String() string {
+	if filter == nil {
+		return "none, all applications are shown"
+	}
+	var parts []string
+	if len(filter.config.Include) > 0 {
+		parts = append(parts, "include "+describeRules(filter.config.Include))
+	}
+	if len(filter.config.Exclude) > 0 {
+		parts = append(parts, "exclude "+describeRules(filter.config.Exclude))
+	}
+	return strings.Join(parts, "; ")
+}
+
+func describeRules(rules []Rule) string {
+	descriptions := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		var conditions []string
+		for _, condition := range []struct{ name, value string }{
+			{"selector", rule.Selector}, {"name", rule.Name}, {"namespace", rule.Namespace}, {"project", rule.Project}, {"server", rule.Server},
+		} {
+			if condition.value != "" {
+				conditions = append(conditions, condition.name+" "+condition.value)
+			}
+		}
+		descriptions = append(descriptions, "("+strings.Join(conditions, ", ")+")")
+	}
+	return strings.Join(descriptions, " or ")
+}
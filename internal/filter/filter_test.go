@@ -0,0 +1,129 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package filter
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"dashboard/internal/app"
+)
+
+func givenApplication(name string, namespace string, project string, server string, labels map[string]string) app.Item {
+	item := app.Item{Metadata: app.Metadata{Name: name, Namespace: "argocd", Labels: labels}}
+	item.Spec.Destination.Namespace = namespace
+	item.Spec.Destination.Server = server
+	item.Spec.Project = project
+	return item
+}
+
+func givenApplications() app.Applications {
+	return app.Applications{Items: []app.Item{
+		givenApplication("edc", "product-edc", "tractusx", "https://kubernetes.default.svc", map[string]string{"team": "connector"}),
+		givenApplication("edc-test", "product-edc-test", "tractusx", "https://kubernetes.default.svc", map[string]string{"team": "connector", "stage": "test"}),
+		givenApplication("portal", "product-portal", "tractusx", "https://remote.example.org", map[string]string{"team": "portal"}),
+		givenApplication("ingress-nginx", "kube-system", "default", "https://kubernetes.default.svc", nil),
+	}}
+}
+
+func TestShouldSelectApplicationsByRules(t *testing.T) {
+	tests := map[string]struct {
+		config   Config
+		expected []string
+	}{
+		"no rules":           {Config{}, []string{"edc", "edc-test", "portal", "ingress-nginx"}},
+		"selector":           {Config{Include: []Rule{{Selector: "team in (connector),stage!=test"}}}, []string{"edc"}},
+		"name glob":          {Config{Include: []Rule{{Name: "edc*"}}}, []string{"edc", "edc-test"}},
+		"namespace regex":    {Config{Include: []Rule{{Namespace: "/^product-(edc|portal)$/"}}}, []string{"edc", "portal"}},
+		"project":            {Config{Exclude: []Rule{{Project: "default"}}}, []string{"edc", "edc-test", "portal"}},
+		"server":             {Config{Include: []Rule{{Server: "https://remote.example.org"}}}, []string{"portal"}},
+		"conditions and":     {Config{Include: []Rule{{Name: "edc*", Selector: "stage=test"}}}, []string{"edc-test"}},
+		"rules or":           {Config{Include: []Rule{{Name: "portal"}, {Namespace: "kube-*"}}}, []string{"portal", "ingress-nginx"}},
+		"exclude wins":       {Config{Include: []Rule{{Project: "tractusx"}}, Exclude: []Rule{{Selector: "stage"}}}, []string{"edc", "portal"}},
+		"ignored namespaces": {Config{Exclude: ExcludeNamespaces([]string{"kube-system", " product-portal"})}, []string{"edc", "edc-test"}},
+	}
+	for name, test := range tests {
+		filter, err := New(test.config)
+		if err != nil {
+			t.Fatalf("%s: could not create filter: %v", name, err)
+		}
+		applications := givenApplications()
+
+		filter.Apply(&applications)
+
+		var names []string
+		for _, item := range applications.Items {
+			names = append(names, item.Metadata.Name)
+		}
+		if !reflect.DeepEqual(names, test.expected) {
+			t.Errorf("%s: wrong applications selected! \nexpected: %v \nGot: %v", name, test.expected, names)
+		}
+	}
+}
+
+func TestShouldRejectInvalidRules(t *testing.T) {
+	tests := map[string]Config{
+		"empty rule": {Exclude: []Rule{{}}},
+		"selector":   {Include: []Rule{{Selector: "team in (connector"}}},
+		"regex":      {Include: []Rule{{Name: "/edc(/"}}},
+		"glob":       {Include: []Rule{{Namespace: "product-[edc"}}},
+	}
+	for name, config := range tests {
+		if _, err := New(config); err == nil {
+			t.Errorf("%s: expected an error for an invalid rule!", name)
+		}
+	}
+}
+
+func TestShouldLoadConfigAndDescribeRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "filters.yaml")
+	content := "include:\n  - namespace: product-*\n    project: tractusx\nexclude:\n  - selector: stage=test\n  - name: /-tmp$/\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := Load(path)
+	if err != nil {
+		t.Fatalf("Could not load filter config: %v", err)
+	}
+	filter, err := New(config)
+	if err != nil {
+		t.Fatalf("Could not create filter: %v", err)
+	}
+
+	expected := "include (namespace product-*, project tractusx); exclude (selector stage=test) or (name /-tmp$/)"
+	if filter.String() != expected {
+		t.Errorf("Filter not described correctly! \nexpected: %s \nGot: %s", expected, filter.String())
+	}
+}
+
+func TestShouldRejectUnknownFieldsInConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "filters.yaml")
+	if err := os.WriteFile(path, []byte("include:\n  - labels: team=edc\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "labels") {
+		t.Errorf("Expected an error naming the unknown field! Got: %v", err)
+	}
+}
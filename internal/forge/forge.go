@@ -0,0 +1,235 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+// Package forge turns repository URLs of different git hosting services into links to trees, commits and
+// comparisons between revisions.
+package forge
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+type Kind string
+
+const (
+	GitHub      Kind = "github"
+	GitLab      Kind = "gitlab"
+	Bitbucket   Kind = "bitbucket"
+	AzureDevOps Kind = "azure"
+	Gitea       Kind = "gitea"
+)
+
+var Kinds = []Kind{GitHub, GitLab, Bitbucket, AzureDevOps, Gitea}
+
+var wellKnownHosts = map[string]Kind{
+	"github.com":        GitHub,
+	"gitlab.com":        GitLab,
+	"bitbucket.org":     Bitbucket,
+	"dev.azure.com":     AzureDevOps,
+	"ssh.dev.azure.com": AzureDevOps,
+	"gitea.com":         Gitea,
+	"codeberg.org":      Gitea,
+}
+
+// Repository is a repository on a forge, identified by the URL of its web interface.
+type Repository struct {
+	Kind Kind
+	Host string
+	// Path of the repository on the host, i.e. owner/repo or for Azure DevOps organization/project/_git/repo
+	Path string
+}
+
+func (repository Repository) WebUrl() string {
+	return "https://" + repository.Host + "/" + repository.Path
+}
+
+func (repository Repository) TreeUrl(revision string) string {
+	switch repository.Kind {
+	case GitLab:
+		return repository.WebUrl() + "/-/tree/" + revision
+	case Bitbucket:
+		return repository.WebUrl() + "/src/" + revision
+	case AzureDevOps:
+		return repository.WebUrl() + "?version=" + azureVersion(revision)
+	case Gitea:
+		if IsCommitHash(revision) {
+			return repository.WebUrl() + "/src/commit/" + revision
+		}
+		return repository.WebUrl() + "/src/" + revision
+	default:
+		return repository.WebUrl() + "/tree/" + revision
+	}
+}
+
+func (repository Repository) CommitUrl(revision string) string {
+	switch repository.Kind {
+	case GitLab:
+		return repository.WebUrl() + "/-/commit/" + revision
+	case Bitbucket:
+		return repository.WebUrl() + "/commits/" + revision
+	default:
+		return repository.WebUrl() + "/commit/" + revision
+	}
+}
+
+// CompareUrl links to the changes between the revisions from and to.
+func (repository Repository) CompareUrl(from string, to string) string {
+	switch repository.Kind {
+	case GitLab:
+		return repository.WebUrl() + "/-/compare/" + from + "..." + to
+	case Bitbucket:
+		return repository.WebUrl() + "/branches/compare/" + to + "%0D" + from + "#diff"
+	case AzureDevOps:
+		return repository.WebUrl() + "/branchCompare?baseVersion=" + azureVersion(from) + "&targetVersion=" + azureVersion(to)
+	default:
+		return repository.WebUrl() + "/compare/" + from + "..." + to
+	}
+}
+
+// azureVersion prefixes the revision with GC for commits or GB for branches, as Azure DevOps requires.
+func azureVersion(revision string) string {
+	if IsCommitHash(revision) {
+		return "GC" + revision
+	}
+	return "GB" + url.QueryEscape(revision)
+}
+
+// IsCommitHash reports whether the revision is a full SHA-1 or SHA-256 commit hash rather than a branch or tag.
+func IsCommitHash(revision string) bool {
+	if len(revision) != 40 && len(revision) != 64 {
+		return false
+	}
+	return strings.Trim(strings.ToLower(revision), "0123456789abcdef") == ""
+}
+
+// Resolver recognises repositories on well-known forges and on custom hosts, like GitHub Enterprise or
+// self-hosted GitLab instances.
+type Resolver struct {
+	hosts map[string]Kind
+}
+
+// NewResolver creates a resolver that knows the given custom hosts in addition to the well-known forges.
+func NewResolver(customHosts map[string]Kind) *Resolver {
+	hosts := make(map[string]Kind, len(wellKnownHosts)+len(customHosts))
+	for host, kind := range wellKnownHosts {
+		hosts[host] = kind
+	}
+	for host, kind := range customHosts {
+		hosts[strings.ToLower(host)] = kind
+	}
+	return &Resolver{hosts: hosts}
+}
+
+// ParseKind returns the kind for its name, i.e. github or gitlab.
+func ParseKind(name string) (Kind, error) {
+	for _, kind := range Kinds {
+		if string(kind) == strings.ToLower(strings.TrimSpace(name)) {
+			return kind, nil
+		}
+	}
+	return "", fmt.Errorf("unknown forge %q", name)
+}
+
+// Resolve parses SSH and HTTPS clone URLs. Hosts that are neither well-known nor configured are guessed from their
+// name and treated like GitHub otherwise, which also covers GitHub Enterprise.
+func (resolver *Resolver) Resolve(repoUrl string) (Repository, bool) {
+	host, path, ok := splitRepoUrl(strings.TrimSpace(repoUrl))
+	if !ok {
+		return Repository{}, false
+	}
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+
+	kind := resolver.kindOf(host)
+	if kind == AzureDevOps {
+		host, path = azureWebLocation(host, path)
+	}
+	return Repository{Kind: kind, Host: host, Path: path}, true
+}
+
+func (resolver *Resolver) kindOf(host string) Kind {
+	if kind, found := resolver.hosts[host]; found {
+		return kind
+	}
+	// custom hosts may be configured without the port
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		if kind, found := resolver.hosts[hostname]; found {
+			return kind
+		}
+	}
+	switch {
+	case strings.HasSuffix(host, ".visualstudio.com"):
+		return AzureDevOps
+	case strings.Contains(host, "gitlab"):
+		return GitLab
+	case strings.Contains(host, "gitea"):
+		return Gitea
+	case strings.Contains(host, "bitbucket"):
+		return Bitbucket
+	default:
+		return GitHub
+	}
+}
+
+// splitRepoUrl splits scp-like SSH URLs (git@host:path), ssh:// and http(s):// URLs into host and path. The port of
+// http(s) URLs is kept since the web interface is served there, the one of ssh and git URLs is dropped.
+func splitRepoUrl(repoUrl string) (string, string, bool) {
+	if !strings.Contains(repoUrl, "://") {
+		userAndHost, path, found := strings.Cut(repoUrl, ":")
+		if !found || path == "" {
+			return "", "", false
+		}
+		_, host, _ := strings.Cut(userAndHost, "@")
+		if host == "" {
+			host = userAndHost
+		}
+		return strings.ToLower(host), path, true
+	}
+
+	parsed, err := url.Parse(repoUrl)
+	if err != nil || parsed.Hostname() == "" || strings.Trim(parsed.Path, "/") == "" {
+		return "", "", false
+	}
+	switch parsed.Scheme {
+	case "http", "https":
+		return strings.ToLower(parsed.Host), parsed.Path, true
+	case "ssh", "git":
+		return strings.ToLower(parsed.Hostname()), parsed.Path, true
+	default:
+		return "", "", false
+	}
+}
+
+// azureWebLocation maps the SSH path v3/organization/project/repo and the legacy visualstudio.com host to the web
+// interface on dev.azure.com.
+func azureWebLocation(host string, path string) (string, string) {
+	if host == "ssh.dev.azure.com" || strings.HasPrefix(host, "vs-ssh.") {
+		parts := strings.Split(strings.TrimPrefix(path, "v3/"), "/")
+		if len(parts) == 3 {
+			return "dev.azure.com", parts[0] + "/" + parts[1] + "/_git/" + parts[2]
+		}
+	}
+	if strings.HasSuffix(host, ".visualstudio.com") {
+		organization := strings.TrimSuffix(host, ".visualstudio.com")
+		return "dev.azure.com", organization + "/" + path
+	}
+	return host, path
+}
@@ -0,0 +1,164 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package forge
+
+import "testing"
+
+const commit = "3d7377d0af2683eb89f7c572d7f01fa794260e55"
+
+func TestShouldResolveRepositoryUrlsOfAllForges(t *testing.T) {
+	tests := []struct {
+		repoUrl  string
+		expected Repository
+	}{
+		{"https://github.com/eclipse-tractusx/app-dashboard", Repository{GitHub, "github.com", "eclipse-tractusx/app-dashboard"}},
+		{"https://github.com/eclipse-tractusx/app-dashboard.git", Repository{GitHub, "github.com", "eclipse-tractusx/app-dashboard"}},
+		{"git@github.com:eclipse-tractusx/app-dashboard.git", Repository{GitHub, "github.com", "eclipse-tractusx/app-dashboard"}},
+		{"ssh://git@github.com/eclipse-tractusx/app-dashboard", Repository{GitHub, "github.com", "eclipse-tractusx/app-dashboard"}},
+		{"https://gitlab.com/group/subgroup/project.git", Repository{GitLab, "gitlab.com", "group/subgroup/project"}},
+		{"git@gitlab.com:group/subgroup/project.git", Repository{GitLab, "gitlab.com", "group/subgroup/project"}},
+		{"ssh://git@gitlab.example.org:2222/group/project.git", Repository{GitLab, "gitlab.example.org", "group/project"}},
+		{"https://user@bitbucket.org/owner/repo.git", Repository{Bitbucket, "bitbucket.org", "owner/repo"}},
+		{"git@bitbucket.org:owner/repo.git", Repository{Bitbucket, "bitbucket.org", "owner/repo"}},
+		{"https://org@dev.azure.com/org/project/_git/repo", Repository{AzureDevOps, "dev.azure.com", "org/project/_git/repo"}},
+		{"git@ssh.dev.azure.com:v3/org/project/repo", Repository{AzureDevOps, "dev.azure.com", "org/project/_git/repo"}},
+		{"https://org.visualstudio.com/project/_git/repo", Repository{AzureDevOps, "dev.azure.com", "org/project/_git/repo"}},
+		{"org@vs-ssh.visualstudio.com:v3/org/project/repo", Repository{AzureDevOps, "dev.azure.com", "org/project/_git/repo"}},
+		{"https://codeberg.org/owner/repo.git", Repository{Gitea, "codeberg.org", "owner/repo"}},
+		{"git@gitea.example.org:owner/repo.git", Repository{Gitea, "gitea.example.org", "owner/repo"}},
+		{"https://github.example.org/owner/repo", Repository{GitHub, "github.example.org", "owner/repo"}},
+	}
+
+	for _, test := range tests {
+		repository, ok := NewResolver(nil).Resolve(test.repoUrl)
+
+		if !ok || repository != test.expected {
+			t.Errorf("Repository url %s not resolved correctly! \nexpected: %v \nGot: %v", test.repoUrl, test.expected, repository)
+		}
+	}
+}
+
+func TestShouldUseKindOfCustomHosts(t *testing.T) {
+	resolver := NewResolver(map[string]Kind{"git.example.org": GitLab, "Code.Example.org": Gitea})
+
+	if repository, _ := resolver.Resolve("https://git.example.org/group/project"); repository.Kind != GitLab {
+		t.Errorf("Custom host not resolved as GitLab! Got: %v", repository.Kind)
+	}
+	if repository, _ := resolver.Resolve("git@code.example.org:owner/repo.git"); repository.Kind != Gitea {
+		t.Errorf("Custom host not resolved case-insensitively as Gitea! Got: %v", repository.Kind)
+	}
+}
+
+func TestShouldKeepThePortOfWebUrls(t *testing.T) {
+	resolver := NewResolver(map[string]Kind{"git.example.org": GitLab})
+
+	repository, ok := resolver.Resolve("https://Git.Example.org:8443/team/repo.git")
+
+	expected := Repository{GitLab, "git.example.org:8443", "team/repo"}
+	if !ok || repository != expected {
+		t.Errorf("Port of web url not kept! \nexpected: %v \nGot: %v", expected, repository)
+	}
+	if commitUrl := repository.CommitUrl(commit); commitUrl != "https://git.example.org:8443/team/repo/-/commit/"+commit {
+		t.Errorf("Commit not linked on the port of the web interface! \nexpected: %s \nGot: %s", "https://git.example.org:8443/team/repo/-/commit/"+commit, commitUrl)
+	}
+}
+
+func TestShouldDropTheSshPortOfSshUrls(t *testing.T) {
+	for _, repoUrl := range []string{"ssh://git@git.example.org:2222/team/repo.git", "git://git.example.org:9418/team/repo.git"} {
+		repository, ok := NewResolver(nil).Resolve(repoUrl)
+
+		expected := Repository{GitHub, "git.example.org", "team/repo"}
+		if !ok || repository != expected {
+			t.Errorf("Port of %s not dropped! \nexpected: %v \nGot: %v", repoUrl, expected, repository)
+		}
+	}
+}
+
+func TestShouldNotResolveInvalidUrls(t *testing.T) {
+	for _, repoUrl := range []string{"", "https://github.com", "file:///tmp/repo", "just-a-name"} {
+		if repository, ok := NewResolver(nil).Resolve(repoUrl); ok {
+			t.Errorf("Expected %q not to be resolved! Got: %v", repoUrl, repository)
+		}
+	}
+}
+
+func TestShouldGenerateLinksForEachForge(t *testing.T) {
+	tests := []struct {
+		repository Repository
+		tree       string
+		commit     string
+		compare    string
+	}{
+		{
+			Repository{GitHub, "github.com", "owner/repo"},
+			"https://github.com/owner/repo/tree/main",
+			"https://github.com/owner/repo/commit/" + commit,
+			"https://github.com/owner/repo/compare/v1.0.0...v1.1.0",
+		},
+		{
+			Repository{GitLab, "gitlab.com", "group/project"},
+			"https://gitlab.com/group/project/-/tree/main",
+			"https://gitlab.com/group/project/-/commit/" + commit,
+			"https://gitlab.com/group/project/-/compare/v1.0.0...v1.1.0",
+		},
+		{
+			Repository{Bitbucket, "bitbucket.org", "owner/repo"},
+			"https://bitbucket.org/owner/repo/src/main",
+			"https://bitbucket.org/owner/repo/commits/" + commit,
+			"https://bitbucket.org/owner/repo/branches/compare/v1.1.0%0Dv1.0.0#diff",
+		},
+		{
+			Repository{AzureDevOps, "dev.azure.com", "org/project/_git/repo"},
+			"https://dev.azure.com/org/project/_git/repo?version=GBmain",
+			"https://dev.azure.com/org/project/_git/repo/commit/" + commit,
+			"https://dev.azure.com/org/project/_git/repo/branchCompare?baseVersion=GBv1.0.0&targetVersion=GBv1.1.0",
+		},
+		{
+			Repository{Gitea, "codeberg.org", "owner/repo"},
+			"https://codeberg.org/owner/repo/src/main",
+			"https://codeberg.org/owner/repo/commit/" + commit,
+			"https://codeberg.org/owner/repo/compare/v1.0.0...v1.1.0",
+		},
+	}
+
+	for _, test := range tests {
+		if tree := test.repository.TreeUrl("main"); tree != test.tree {
+			t.Errorf("Wrong tree url for %s! \nexpected: %s \nGot: %s", test.repository.Kind, test.tree, tree)
+		}
+		if commitUrl := test.repository.CommitUrl(commit); commitUrl != test.commit {
+			t.Errorf("Wrong commit url for %s! \nexpected: %s \nGot: %s", test.repository.Kind, test.commit, commitUrl)
+		}
+		if compare := test.repository.CompareUrl("v1.0.0", "v1.1.0"); compare != test.compare {
+			t.Errorf("Wrong compare url for %s! \nexpected: %s \nGot: %s", test.repository.Kind, test.compare, compare)
+		}
+	}
+}
+
+func TestShouldLinkCommitsAsTreeWhereForgesDistinguishRevisionTypes(t *testing.T) {
+	azure := Repository{AzureDevOps, "dev.azure.com", "org/project/_git/repo"}
+	gitea := Repository{Gitea, "codeberg.org", "owner/repo"}
+
+	if tree := azure.TreeUrl(commit); tree != "https://dev.azure.com/org/project/_git/repo?version=GC"+commit {
+		t.Errorf("Commit not linked as commit version on Azure DevOps! Got: %s", tree)
+	}
+	if tree := gitea.TreeUrl(commit); tree != "https://codeberg.org/owner/repo/src/commit/"+commit {
+		t.Errorf("Commit not linked as commit tree on Gitea! Got: %s", tree)
+	}
+}
@@ -0,0 +1,144 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package gateway
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"dashboard/internal/app"
+	"dashboard/internal/filter"
+)
+
+// maxArgoCdResponseSize protects against unreasonably large responses, the applications of big instances take some
+// ten MB.
+const maxArgoCdResponseSize = 256 << 20
+
+// ArgoCdGateway reads the applications through the REST API of the Argo CD server, for environments granting an Argo
+// CD API token but no access to the Kubernetes API. Pods, events and routing objects are not read.
+type ArgoCdGateway struct {
+	client *http.Client
+	server string
+	token  string
+	// projects restricts the applications to these Argo CD projects, all projects if empty
+	projects []string
+	filter   *filter.Filter
+}
+
+// ArgoCdOptions configure the ArgoCdGateway.
+type ArgoCdOptions struct {
+	// Server is the URL of the Argo CD server, i.e. https://argocd.example.org
+	Server string
+	// Token is an Argo CD API token of an account allowed to get the applications
+	Token    string
+	Projects []string
+	// Insecure skips verifying the certificate of the server, i.e. for self-signed certificates
+	Insecure bool
+	Filter   *filter.Filter
+}
+
+func NewArgoCdGateway(client *http.Client, options ArgoCdOptions) (*ArgoCdGateway, error) {
+	server, err := url.Parse(options.Server)
+	if err != nil || (server.Scheme != "https" && server.Scheme != "http") || server.Host == "" {
+		return nil, fmt.Errorf("invalid Argo CD server %q, expected a URL like https://argocd.example.org", options.Server)
+	}
+	if options.Token == "" {
+		return nil, fmt.Errorf("no Argo CD API token configured")
+	}
+
+	if options.Insecure {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+		insecure := *client
+		insecure.Transport = transport
+		client = &insecure
+	}
+	return &ArgoCdGateway{
+		client:   client,
+		server:   strings.TrimSuffix(options.Server, "/"),
+		token:    options.Token,
+		projects: options.Projects,
+		filter:   options.Filter,
+	}, nil
+}
+
+func (gateway *ArgoCdGateway) GetApplications(ctx context.Context) (app.Applications, error) {
+	query := url.Values{}
+	for _, project := range gateway.projects {
+		query.Add("projects", project)
+	}
+
+	var applications app.Applications
+	if err := gateway.get(ctx, "/api/v1/applications", query, &applications); err != nil {
+		return app.Applications{}, err
+	}
+	if applications.Items == nil {
+		// Argo CD leaves out the items if there are none
+		applications.Items = []app.Item{}
+	}
+
+	gateway.filter.Apply(&applications)
+	transformApplicationsResponse(applications)
+	return applications, nil
+}
+
+// About leaves out the Kubernetes version, reading it through Argo CD needs permission to get its clusters.
+func (gateway *ArgoCdGateway) About(ctx context.Context) app.GatewayInfo {
+	version := struct {
+		Version string `json:"Version"`
+	}{}
+	if err := gateway.get(ctx, "/api/version", nil, &version); err != nil || version.Version == "" {
+		version.Version = "unknown"
+	}
+	return app.GatewayInfo{Source: gateway.server, Tool: "Argo CD", ToolVersion: version.Version}
+}
+
+func (gateway *ArgoCdGateway) get(ctx context.Context, path string, query url.Values, result any) error {
+	requestUrl := gateway.server + path
+	if len(query) > 0 {
+		requestUrl += "?" + query.Encode()
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, requestUrl, nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Authorization", "Bearer "+gateway.token)
+	request.Header.Set("Accept", "application/json")
+
+	response, err := gateway.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("could not reach Argo CD: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("got an error from the Argo CD API, %s answered with %s", path, response.Status)
+	}
+	if err := json.NewDecoder(io.LimitReader(response.Body, maxArgoCdResponseSize)).Decode(result); err != nil {
+		return fmt.Errorf("could not parse the response of the Argo CD API to %s: %w", path, err)
+	}
+	return nil
+}
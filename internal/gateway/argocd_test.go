@@ -0,0 +1,176 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"dashboard/internal/filter"
+)
+
+const argoCdToken = "secret-token"
+
+// givenArgoCdServer stands in for the Argo CD API, answering with the applications of the requested projects.
+func givenArgoCdServer(t *testing.T, tls bool) *httptest.Server {
+	applicationsByProject := map[string]string{"tractusx": "edc", "sandbox": "playground"}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+argoCdToken {
+			http.Error(w, `{"error":"invalid session"}`, http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/v1/applications":
+			var names []string
+			for project, name := range applicationsByProject {
+				if projects := r.URL.Query()["projects"]; len(projects) == 0 || contains(projects, project) {
+					names = append(names, name)
+				}
+			}
+			if len(names) == 0 {
+				_, _ = w.Write([]byte(`{"metadata":{}}`))
+				return
+			}
+			sort.Strings(names)
+			_, _ = w.Write([]byte(applicationList(names...)))
+		case "/api/version":
+			_, _ = w.Write([]byte(`{"Version":"v2.8.4+c279299"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	var server *httptest.Server
+	if tls {
+		server = httptest.NewTLSServer(handler)
+	} else {
+		server = httptest.NewServer(handler)
+	}
+	t.Cleanup(server.Close)
+	return server
+}
+
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
+
+func whenListingThroughArgoCd(t *testing.T, server *httptest.Server, options ArgoCdOptions) ([]string, error) {
+	options.Server = server.URL
+	gateway, err := NewArgoCdGateway(&http.Client{Timeout: 5 * time.Second}, options)
+	if err != nil {
+		t.Fatalf("Could not create gateway: %v", err)
+	}
+	applications, err := gateway.GetApplications(context.Background())
+	names := []string{}
+	for _, item := range applications.Items {
+		names = append(names, item.Metadata.Name)
+	}
+	return names, err
+}
+
+func TestShouldListApplicationsThroughArgoCdWithBearerToken(t *testing.T) {
+	names, err := whenListingThroughArgoCd(t, givenArgoCdServer(t, false), ArgoCdOptions{Token: argoCdToken})
+
+	if err != nil || !reflect.DeepEqual(names, []string{"edc", "playground"}) {
+		t.Errorf("Applications not listed! \nexpected: %v \nGot: %v %v", []string{"edc", "playground"}, names, err)
+	}
+}
+
+func TestShouldListApplicationsOfTheConfiguredProjects(t *testing.T) {
+	server := givenArgoCdServer(t, false)
+
+	names, err := whenListingThroughArgoCd(t, server, ArgoCdOptions{Token: argoCdToken, Projects: []string{"tractusx"}})
+	if err != nil || !reflect.DeepEqual(names, []string{"edc"}) {
+		t.Errorf("Applications not filtered by project! \nexpected: %v \nGot: %v %v", []string{"edc"}, names, err)
+	}
+
+	names, err = whenListingThroughArgoCd(t, server, ArgoCdOptions{Token: argoCdToken, Projects: []string{"unknown"}})
+	if err != nil || len(names) != 0 {
+		t.Errorf("Expected no applications for an unknown project! Got: %v %v", names, err)
+	}
+}
+
+func TestShouldApplyFilterToApplicationsOfArgoCd(t *testing.T) {
+	applicationFilter, err := filter.New(filter.Config{Exclude: []filter.Rule{{Name: "play*"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names, err := whenListingThroughArgoCd(t, givenArgoCdServer(t, false), ArgoCdOptions{Token: argoCdToken, Filter: applicationFilter})
+
+	if err != nil || !reflect.DeepEqual(names, []string{"edc"}) {
+		t.Errorf("Applications not filtered! \nexpected: %v \nGot: %v %v", []string{"edc"}, names, err)
+	}
+}
+
+func TestShouldReportRejectedTokensWithoutLeakingThem(t *testing.T) {
+	_, err := whenListingThroughArgoCd(t, givenArgoCdServer(t, false), ArgoCdOptions{Token: "expired-token"})
+
+	if err == nil || !strings.Contains(err.Error(), "401") || strings.Contains(err.Error(), "expired-token") {
+		t.Errorf("Expected an error naming the status but not the token! Got: %v", err)
+	}
+}
+
+func TestShouldAcceptSelfSignedCertificatesOnlyIfInsecure(t *testing.T) {
+	server := givenArgoCdServer(t, true)
+
+	if _, err := whenListingThroughArgoCd(t, server, ArgoCdOptions{Token: argoCdToken}); err == nil {
+		t.Errorf("Expected an error for the self-signed certificate!")
+	}
+	if names, err := whenListingThroughArgoCd(t, server, ArgoCdOptions{Token: argoCdToken, Insecure: true}); err != nil || len(names) != 2 {
+		t.Errorf("Applications not listed with insecure TLS! Got: %v %v", names, err)
+	}
+}
+
+func TestShouldShowArgoCdVersion(t *testing.T) {
+	server := givenArgoCdServer(t, false)
+	gateway, err := NewArgoCdGateway(server.Client(), ArgoCdOptions{Server: server.URL + "/", Token: argoCdToken})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info := gateway.About(context.Background())
+
+	if info.ToolVersion != "v2.8.4+c279299" || info.Source != server.URL || info.Tool != "Argo CD" {
+		t.Errorf("Argo CD version not read! \nexpected: %s \nGot: %v", "Argo CD v2.8.4+c279299", info)
+	}
+}
+
+func TestShouldRejectInvalidArgoCdConfiguration(t *testing.T) {
+	for name, options := range map[string]ArgoCdOptions{
+		"no server": {Token: argoCdToken},
+		"no scheme": {Server: "argocd.example.org", Token: argoCdToken},
+		"no token":  {Server: "https://argocd.example.org"},
+	} {
+		if _, err := NewArgoCdGateway(http.DefaultClient, options); err == nil {
+			t.Errorf("%s: expected an error!", name)
+		}
+	}
+}
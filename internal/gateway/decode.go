@@ -0,0 +1,101 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package gateway
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"dashboard/internal/app"
+)
+
+// decodeApplications decodes a list of applications item by item from the stream and appends the items kept, so
+// neither the whole response nor the applications filtered out are held in memory at once. Only the fields of
+// app.Item are decoded, fields it doesn't model, like the managed fields of the metadata, are skipped. It returns the
+// continue token of the list, empty for the last page.
+func decodeApplications(reader io.Reader, applications *app.Applications, keep func(app.Item) bool) (string, error) {
+	decoder := json.NewDecoder(reader)
+	if err := expectDelim(decoder, '{'); err != nil {
+		return "", err
+	}
+
+	continueToken := ""
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return "", err
+		}
+		switch token {
+		case "apiVersion":
+			err = decoder.Decode(&applications.ApiVersion)
+		case "kind":
+			err = decoder.Decode(&applications.Kind)
+		case "metadata":
+			var metadata struct {
+				Continue string `json:"continue"`
+			}
+			err = decoder.Decode(&metadata)
+			continueToken = metadata.Continue
+		case "items":
+			err = decodeItems(decoder, applications, keep)
+		default:
+			var skipped json.RawMessage
+			err = decoder.Decode(&skipped)
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return continueToken, expectDelim(decoder, '}')
+}
+
+func decodeItems(decoder *json.Decoder, applications *app.Applications, keep func(app.Item) bool) error {
+	token, err := decoder.Token()
+	if err != nil || token == nil {
+		// the items are null if there are none
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("expected the items to be a list, got %v", token)
+	}
+
+	for decoder.More() {
+		var item app.Item
+		if err := decoder.Decode(&item); err != nil {
+			return err
+		}
+		if keep(item) {
+			applications.Items = append(applications.Items, item)
+		}
+	}
+	return expectDelim(decoder, ']')
+}
+
+func expectDelim(decoder *json.Decoder, expected json.Delim) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != expected {
+		return fmt.Errorf("expected %v, got %v", expected, token)
+	}
+	return nil
+}
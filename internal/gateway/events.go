@@ -0,0 +1,141 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package gateway
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"dashboard/internal/app"
+)
+
+var now = time.Now
+
+// addEvents reads the recent Warning events of the destination namespaces and assigns them to the applications
+// owning the involved objects. Like pods, events are optional, so errors are only logged.
+func (gateway *ApplicationGateway) addEvents(ctx context.Context, applications app.Applications) {
+	since := now().Add(-gateway.options.EventWindow)
+	byNamespace, namespaces := applicationsByNamespace(applications)
+
+	for _, namespace := range namespaces {
+		events, err := gateway.clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{FieldSelector: "type=Warning"})
+		if err != nil {
+			log.Printf("Could not list events in namespace %s: %v", namespace, err)
+			continue
+		}
+
+		indexes := byNamespace[namespace]
+		for _, event := range events.Items {
+			lastSeen := lastSeenOf(event)
+			if event.Type != corev1.EventTypeWarning || lastSeen.Before(since) {
+				continue
+			}
+			if i, found := involvedApplication(event.InvolvedObject, applications, indexes); found {
+				applications.Items[i].Events = append(applications.Items[i].Events, app.Event{
+					Type:     event.Type,
+					Reason:   event.Reason,
+					Message:  event.Message,
+					Object:   event.InvolvedObject.Kind + "/" + event.InvolvedObject.Name,
+					Count:    countOf(event),
+					LastSeen: lastSeen,
+				})
+			}
+		}
+
+		for _, i := range indexes {
+			applications.Items[i].Events = newestEvents(applications.Items[i].Events, gateway.options.EventLimit)
+		}
+	}
+}
+
+// involvedApplication finds the application managing the involved object. Pods and ReplicaSets aren't managed
+// directly, so they are matched by their name starting with the name of a managed resource, or by the pods read
+// before. Events of unknown objects belong to the only application of the namespace, if there is just one.
+func involvedApplication(object corev1.ObjectReference, applications app.Applications, indexes []int) (int, bool) {
+	for _, i := range indexes {
+		item := applications.Items[i]
+		for _, resource := range item.Status.Resources {
+			if resource.Kind == object.Kind && resource.Name == object.Name {
+				return i, true
+			}
+		}
+		if item.Pods != nil && object.Kind == "Pod" {
+			for _, container := range item.Pods.Containers {
+				if container.Pod == object.Name {
+					return i, true
+				}
+			}
+		}
+	}
+
+	for _, i := range indexes {
+		for _, resource := range applications.Items[i].Status.Resources {
+			if (resource.Kind == "Deployment" || resource.Kind == "StatefulSet" || resource.Kind == "DaemonSet" || resource.Kind == "Job") &&
+				strings.HasPrefix(object.Name, resource.Name+"-") {
+				return i, true
+			}
+		}
+	}
+
+	if len(indexes) == 1 {
+		return indexes[0], true
+	}
+	return 0, false
+}
+
+// lastSeenOf returns when the event happened last, which is recorded differently by the events.k8s.io and the core
+// API.
+func lastSeenOf(event corev1.Event) time.Time {
+	switch {
+	case event.Series != nil && !event.Series.LastObservedTime.IsZero():
+		return event.Series.LastObservedTime.Time
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	}
+	return event.CreationTimestamp.Time
+}
+
+func countOf(event corev1.Event) int {
+	if event.Series != nil && event.Series.Count > 0 {
+		return int(event.Series.Count)
+	}
+	if event.Count > 0 {
+		return int(event.Count)
+	}
+	return 1
+}
+
+func newestEvents(events []app.Event, limit int) []app.Event {
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].LastSeen.After(events[j].LastSeen)
+	})
+	if limit > 0 && len(events) > limit {
+		return events[:limit]
+	}
+	return events
+}
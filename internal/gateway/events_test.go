@@ -0,0 +1,96 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package gateway
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"dashboard/internal/app"
+)
+
+func event(namespace string, name string, eventType string, kind string, object string, lastSeen time.Time) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Type:           eventType,
+		Reason:         "BackOff",
+		Message:        "Back-off restarting failed container",
+		InvolvedObject: corev1.ObjectReference{Kind: kind, Name: object, Namespace: namespace},
+		LastTimestamp:  metav1.NewTime(lastSeen),
+		Count:          3,
+	}
+}
+
+func givenCurrentTime(t *testing.T) time.Time {
+	current := time.Date(2023, 10, 20, 8, 0, 0, 0, time.UTC)
+	now = func() time.Time { return current }
+	t.Cleanup(func() { now = time.Now })
+	return current
+}
+
+func TestShouldAddRecentWarningEventsToApplicationsOwningTheObjects(t *testing.T) {
+	current := givenCurrentTime(t)
+	clientset := fake.NewSimpleClientset(
+		event("product-edc", "e1", corev1.EventTypeWarning, "Pod", "controlplane-5d8f-x2k", current.Add(-10*time.Minute)),
+		event("product-edc", "e2", corev1.EventTypeWarning, "StatefulSet", "vault", current.Add(-5*time.Minute)),
+		event("product-edc", "e3", corev1.EventTypeNormal, "Pod", "controlplane-5d8f-x2k", current.Add(-time.Minute)),
+		event("product-edc", "e4", corev1.EventTypeWarning, "Pod", "controlplane-5d8f-x2k", current.Add(-2*time.Hour)),
+		event("product-edc", "e5", corev1.EventTypeWarning, "Pod", "controlplane-5d8f-abc", current.Add(-time.Minute)),
+	)
+	edc := application("edc", "product-edc")
+	edc.Status.Resources = []app.Resource{{Kind: "Deployment", Name: "controlplane"}}
+	vault := application("vault", "product-edc")
+	vault.Status.Resources = []app.Resource{{Kind: "StatefulSet", Name: "vault"}}
+	applications := app.Applications{Items: []app.Item{edc, vault}}
+
+	gateway := &ApplicationGateway{clientset: clientset, options: Options{Events: true, EventWindow: time.Hour, EventLimit: 20}}
+	gateway.addEvents(context.Background(), applications)
+
+	edcEvents := applications.Items[0].Events
+	if len(edcEvents) != 2 || edcEvents[0].Object != "Pod/controlplane-5d8f-abc" || edcEvents[1].Count != 3 || edcEvents[1].Reason != "BackOff" {
+		t.Errorf("Recent warnings of edc not added newest first! \nexpected: %s \nGot: %v", "Pod/controlplane-5d8f-abc, Pod/controlplane-5d8f-x2k", edcEvents)
+	}
+	if vaultEvents := applications.Items[1].Events; len(vaultEvents) != 1 || vaultEvents[0].Object != "StatefulSet/vault" {
+		t.Errorf("Warnings of vault not added! \nexpected: %s \nGot: %v", "StatefulSet/vault", vaultEvents)
+	}
+}
+
+func TestShouldLimitEventsPerApplication(t *testing.T) {
+	current := givenCurrentTime(t)
+	var objects []runtime.Object
+	for i := 0; i < 5; i++ {
+		objects = append(objects, event("product-irs", "e"+string(rune('a'+i)), corev1.EventTypeWarning, "Pod", "irs-1", current.Add(-time.Duration(i)*time.Minute)))
+	}
+	applications := app.Applications{Items: []app.Item{application("irs", "product-irs")}}
+
+	gateway := &ApplicationGateway{clientset: fake.NewSimpleClientset(objects...), options: Options{Events: true, EventWindow: time.Hour, EventLimit: 2}}
+	gateway.addEvents(context.Background(), applications)
+
+	events := applications.Items[0].Events
+	if len(events) != 2 || !events[0].LastSeen.Equal(current) {
+		t.Errorf("Events not limited to the newest! \nexpected: %d, newest %v \nGot: %v", 2, current, events)
+	}
+}
@@ -0,0 +1,114 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"sigs.k8s.io/yaml"
+
+	"dashboard/internal/app"
+	"dashboard/internal/filter"
+)
+
+// FixtureGateway replays recorded applications instead of reading a cluster, for UI work, demos and end-to-end tests
+// without Argo CD. The fixture is a snapshot written by "dashboard snapshot" or the output of
+// "kubectl get applications.argoproj.io -o json", or a directory of them. The snapshots of a directory are replayed
+// in the order of their names, i.e. timestamps, one per sync, starting over after the last one.
+type FixtureGateway struct {
+	path   string
+	files  []string
+	filter *filter.Filter
+
+	mutex sync.Mutex
+	// next is the index of the file returned by the next sync
+	next int
+	// current is the file returned by the last sync
+	current string
+}
+
+// fixtureExtensions are the files of a fixture directory that are replayed, others like a README are skipped.
+var fixtureExtensions = map[string]bool{".json": true, ".yaml": true, ".yml": true}
+
+func NewFixtureGateway(path string, applicationFilter *filter.Filter) (*FixtureGateway, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read fixture: %w", err)
+	}
+	if !info.IsDir() {
+		return &FixtureGateway{path: path, files: []string{path}, filter: applicationFilter}, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read fixture: %w", err)
+	}
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() && fixtureExtensions[strings.ToLower(filepath.Ext(entry.Name()))] {
+			files = append(files, filepath.Join(path, entry.Name()))
+		}
+	}
+	if len(files) == 0 {
+		return nil, errors.New("fixture directory " + path + " contains no JSON or YAML snapshots")
+	}
+	sort.Strings(files)
+	return &FixtureGateway{path: path, files: files, filter: applicationFilter}, nil
+}
+
+// GetApplications reads the file on every sync, so edits show up without a restart.
+func (gateway *FixtureGateway) GetApplications(_ context.Context) (app.Applications, error) {
+	gateway.mutex.Lock()
+	file := gateway.files[gateway.next]
+	gateway.next = (gateway.next + 1) % len(gateway.files)
+	gateway.current = file
+	gateway.mutex.Unlock()
+
+	applications := app.Applications{Items: []app.Item{}}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return applications, fmt.Errorf("could not read fixture: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &applications); err != nil {
+		return app.Applications{}, fmt.Errorf("could not parse fixture %s: %w", file, err)
+	}
+
+	gateway.filter.Apply(&applications)
+	transformApplicationsResponse(applications)
+	return applications, nil
+}
+
+func (gateway *FixtureGateway) About(_ context.Context) app.GatewayInfo {
+	gateway.mutex.Lock()
+	current := gateway.current
+	gateway.mutex.Unlock()
+
+	if len(gateway.files) > 1 {
+		return app.GatewayInfo{Source: fmt.Sprintf("Fixture %s of %d snapshots in %s", filepath.Base(current), len(gateway.files), gateway.path)}
+	}
+	return app.GatewayInfo{Source: "Fixture " + gateway.path}
+}
@@ -0,0 +1,107 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package gateway
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"dashboard/internal/filter"
+)
+
+func givenFixtureFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func namesOfFixture(t *testing.T, gateway *FixtureGateway) []string {
+	applications, err := gateway.GetApplications(context.Background())
+	if err != nil {
+		t.Fatalf("Fixture not replayed! Got: %v", err)
+	}
+	names := []string{}
+	for _, item := range applications.Items {
+		names = append(names, item.Metadata.Name)
+	}
+	return names
+}
+
+func TestShouldReplayApplicationsOfFixtureFile(t *testing.T) {
+	dir := givenFixtureFiles(t, map[string]string{"applications.json": applicationList("edc", "portal")})
+	applicationFilter, err := filter.New(filter.Config{Exclude: []filter.Rule{{Name: "portal"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gateway, err := NewFixtureGateway(filepath.Join(dir, "applications.json"), applicationFilter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if names := namesOfFixture(t, gateway); !reflect.DeepEqual(names, []string{"edc"}) {
+			t.Errorf("Applications of fixture not replayed! \nexpected: %v \nGot: %v", []string{"edc"}, names)
+		}
+	}
+	if info := gateway.About(context.Background()); !strings.HasSuffix(info.Source, "applications.json") {
+		t.Errorf("Fixture not shown! \nexpected: %s \nGot: %s", "applications.json", info.Source)
+	}
+}
+
+func TestShouldReplaySnapshotsOfFixtureDirectoryInOrder(t *testing.T) {
+	dir := givenFixtureFiles(t, map[string]string{
+		"2023-10-20T09:00.yaml": "items:\n  - metadata:\n      name: portal\n",
+		"2023-10-20T08:00.json": applicationList("edc"),
+		"README.md":             "Recorded with dashboard snapshot",
+	})
+
+	gateway, err := NewFixtureGateway(dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var replayed []string
+	for i := 0; i < 3; i++ {
+		replayed = append(replayed, namesOfFixture(t, gateway)...)
+	}
+	if expected := []string{"edc", "portal", "edc"}; !reflect.DeepEqual(replayed, expected) {
+		t.Errorf("Snapshots not replayed in order! \nexpected: %v \nGot: %v", expected, replayed)
+	}
+	if info := gateway.About(context.Background()); !strings.Contains(info.Source, "2023-10-20T08:00.json of 2 snapshots") {
+		t.Errorf("Replayed snapshot not shown! \nexpected: %s \nGot: %s", "2023-10-20T08:00.json of 2 snapshots", info.Source)
+	}
+}
+
+func TestShouldRejectFixturesWithoutSnapshots(t *testing.T) {
+	for _, path := range []string{givenFixtureFiles(t, map[string]string{"README.md": ""}), filepath.Join(t.TempDir(), "missing.json")} {
+		if _, err := NewFixtureGateway(path, nil); err == nil {
+			t.Errorf("Fixture %s without snapshots should be rejected!", path)
+		}
+	}
+}
@@ -0,0 +1,380 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"dashboard/internal/app"
+)
+
+// The API versions are tried in order, so the gateway works with the Flux releases of the last years.
+var (
+	kustomizationVersions = fluxVersions("kustomize.toolkit.fluxcd.io", "kustomizations", "v1", "v1beta2")
+	helmReleaseVersions   = fluxVersions("helm.toolkit.fluxcd.io", "helmreleases", "v2", "v2beta2", "v2beta1")
+	sourceVersions        = map[string][]schema.GroupVersionResource{
+		"GitRepository":  fluxVersions("source.toolkit.fluxcd.io", "gitrepositories", "v1", "v1beta2"),
+		"HelmRepository": fluxVersions("source.toolkit.fluxcd.io", "helmrepositories", "v1", "v1beta2"),
+		"OCIRepository":  fluxVersions("source.toolkit.fluxcd.io", "ocirepositories", "v1", "v1beta2"),
+		"Bucket":         fluxVersions("source.toolkit.fluxcd.io", "buckets", "v1", "v1beta2"),
+	}
+	workloadResources = []schema.GroupVersionResource{
+		{Group: "apps", Version: "v1", Resource: "deployments"},
+		{Group: "apps", Version: "v1", Resource: "statefulsets"},
+		{Group: "apps", Version: "v1", Resource: "daemonsets"},
+	}
+)
+
+// Flux labels the objects it applies with the name and namespace of the Kustomization or HelmRelease.
+const (
+	kustomizeNameLabel      = "kustomize.toolkit.fluxcd.io/name"
+	kustomizeNamespaceLabel = "kustomize.toolkit.fluxcd.io/namespace"
+	helmNameLabel           = "helm.toolkit.fluxcd.io/name"
+	helmNamespaceLabel      = "helm.toolkit.fluxcd.io/namespace"
+)
+
+// commitPattern matches the commit of Flux revisions like main@sha1:<sha> or the older main/<sha>.
+var commitPattern = regexp.MustCompile(`(?:sha1:|/)([0-9a-f]{40})$`)
+
+func fluxVersions(group string, resource string, versions ...string) []schema.GroupVersionResource {
+	result := make([]schema.GroupVersionResource, 0, len(versions))
+	for _, version := range versions {
+		result = append(result, schema.GroupVersionResource{Group: group, Version: version, Resource: resource})
+	}
+	return result
+}
+
+// FluxGateway reads the Kustomizations and HelmReleases of Flux and maps them onto the applications of the
+// dashboard. Pods, events and URLs are read like for Argo CD applications.
+type FluxGateway struct {
+	kubernetes *ApplicationGateway
+}
+
+func NewFluxGateway(inCluster bool, kubeconfig string, options Options) (*FluxGateway, error) {
+	kubernetesGateway, err := NewApplicationGateway(inCluster, kubeconfig, options)
+	if err != nil {
+		return nil, err
+	}
+	return &FluxGateway{kubernetes: kubernetesGateway}, nil
+}
+
+type fluxSource struct {
+	url string
+	// ref is the branch, tag, semver range or commit followed
+	ref string
+	// revision is the latest revision fetched by the source controller
+	revision string
+}
+
+func (gateway *FluxGateway) GetApplications(ctx context.Context) (app.Applications, error) {
+	sources, err := gateway.listSources(ctx)
+	if err != nil {
+		return app.Applications{}, err
+	}
+	kustomizations, err := gateway.listServed(ctx, kustomizationVersions)
+	if err != nil {
+		return app.Applications{}, fmt.Errorf("could not list Kustomizations: %w", err)
+	}
+	helmReleases, err := gateway.listServed(ctx, helmReleaseVersions)
+	if err != nil {
+		return app.Applications{}, fmt.Errorf("could not list HelmReleases: %w", err)
+	}
+
+	applications := app.Applications{Items: []app.Item{}}
+	for _, kustomization := range kustomizations {
+		applications.Items = append(applications.Items, kustomizationToItem(kustomization, sources))
+	}
+	for _, helmRelease := range helmReleases {
+		applications.Items = append(applications.Items, helmReleaseToItem(helmRelease, sources))
+	}
+
+	options := gateway.kubernetes.options
+	options.Filter.Apply(&applications)
+	if err := gateway.addWorkloadImages(ctx, applications); err != nil {
+		return app.Applications{}, err
+	}
+	transformApplicationsResponse(applications)
+
+	if options.PodStatus {
+		gateway.kubernetes.addPods(ctx, applications)
+	}
+	if options.Events {
+		gateway.kubernetes.addEvents(ctx, applications)
+	}
+	if options.DiscoverUrls {
+		gateway.kubernetes.addDiscoveredUrls(ctx, applications)
+	}
+	return applications, nil
+}
+
+func (gateway *FluxGateway) About(ctx context.Context) app.GatewayInfo {
+	return app.GatewayInfo{
+		Source:            "Kubernetes API",
+		KubernetesVersion: getClusterVersion(gateway.kubernetes),
+		Tool:              "Flux",
+		ToolVersion:       gateway.kubernetes.deploymentVersion(ctx, gateway.kubernetes.toolNamespace("flux-system"), "app.kubernetes.io/part-of=flux"),
+	}
+}
+
+// listServed lists the objects of the first version served by the cluster, none if the CRD is not installed.
+func (gateway *FluxGateway) listServed(ctx context.Context, versions []schema.GroupVersionResource) ([]unstructured.Unstructured, error) {
+	for _, version := range versions {
+		list, err := gateway.kubernetes.dynamicClient.Resource(version).List(ctx, metav1.ListOptions{})
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return list.Items, nil
+	}
+	return nil, nil
+}
+
+// listSources indexes the Flux sources by <kind>/<namespace>/<name>.
+func (gateway *FluxGateway) listSources(ctx context.Context) (map[string]fluxSource, error) {
+	sources := map[string]fluxSource{}
+	for kind, versions := range sourceVersions {
+		objects, err := gateway.listServed(ctx, versions)
+		if err != nil {
+			return nil, fmt.Errorf("could not list %s sources: %w", kind, err)
+		}
+		for _, object := range objects {
+			source := fluxSource{}
+			source.url, _, _ = unstructured.NestedString(object.Object, "spec", "url")
+			source.revision, _, _ = unstructured.NestedString(object.Object, "status", "artifact", "revision")
+			ref, _, _ := unstructured.NestedStringMap(object.Object, "spec", "ref")
+			for _, key := range []string{"commit", "tag", "semver", "name", "branch"} {
+				if ref[key] != "" {
+					source.ref = ref[key]
+					break
+				}
+			}
+			sources[kind+"/"+object.GetNamespace()+"/"+object.GetName()] = source
+		}
+	}
+	return sources, nil
+}
+
+func sourceRefKey(object unstructured.Unstructured, fields ...string) string {
+	ref, _, _ := unstructured.NestedStringMap(object.Object, fields...)
+	namespace := ref["namespace"]
+	if namespace == "" {
+		namespace = object.GetNamespace()
+	}
+	return ref["kind"] + "/" + namespace + "/" + ref["name"]
+}
+
+func fluxItem(object unstructured.Unstructured) app.Item {
+	item := app.Item{
+		ApiVersion: object.GetAPIVersion(),
+		Kind:       object.GetKind(),
+		Metadata: app.Metadata{
+			Name:        object.GetName(),
+			Namespace:   object.GetNamespace(),
+			Labels:      object.GetLabels(),
+			Annotations: object.GetAnnotations(),
+		},
+	}
+	item.Spec.Destination.Namespace, _, _ = unstructured.NestedString(object.Object, "spec", "targetNamespace")
+	if item.Spec.Destination.Namespace == "" {
+		item.Spec.Destination.Namespace = object.GetNamespace()
+	}
+	return item
+}
+
+func kustomizationToItem(object unstructured.Unstructured, sources map[string]fluxSource) app.Item {
+	item := fluxItem(object)
+	source := sources[sourceRefKey(object, "spec", "sourceRef")]
+	item.Spec.Source = app.Source{RepoUrl: source.url, TargetRevision: source.ref}
+	item.Spec.Source.Path, _, _ = unstructured.NestedString(object.Object, "spec", "path")
+
+	entries, _, _ := unstructured.NestedSlice(object.Object, "status", "inventory", "entries")
+	for _, entry := range entries {
+		if resource, ok := inventoryResource(asMap(entry)); ok {
+			item.Status.Resources = append(item.Status.Resources, resource)
+		}
+	}
+
+	lastApplied, _, _ := unstructured.NestedString(object.Object, "status", "lastAppliedRevision")
+	setFluxStatus(&item, object, lastApplied, revisionOf(lastApplied), source.revision)
+	return item
+}
+
+func helmReleaseToItem(object unstructured.Unstructured, sources map[string]fluxSource) app.Item {
+	item := fluxItem(object)
+	source := sources[sourceRefKey(object, "spec", "chart", "spec", "sourceRef")]
+	item.Spec.Source.RepoUrl = source.url
+	item.Spec.Source.Chart, _, _ = unstructured.NestedString(object.Object, "spec", "chart", "spec", "chart")
+	item.Spec.Source.TargetRevision, _, _ = unstructured.NestedString(object.Object, "spec", "chart", "spec", "version")
+
+	// v2 keeps a history of the releases, the older versions only the last applied chart version
+	lastApplied, _, _ := unstructured.NestedString(object.Object, "status", "lastAppliedRevision")
+	if item.Status.History = helmReleaseHistory(object, item.Spec.Source); len(item.Status.History) > 0 {
+		lastApplied = item.Status.History[0].Revision
+	}
+	setFluxStatus(&item, object, lastApplied, lastApplied, "")
+	return item
+}
+
+// helmReleaseHistory maps the releases in the status of a v2 HelmRelease, newest first, onto the history. Failed
+// releases are left out like failed syncs of Argo CD.
+func helmReleaseHistory(object unstructured.Unstructured, source app.Source) []app.History {
+	releases, _, _ := unstructured.NestedSlice(object.Object, "status", "history")
+	var history []app.History
+	for i, release := range releases {
+		fields := asMap(release)
+		if status, _, _ := unstructured.NestedString(fields, "status"); status == "failed" {
+			continue
+		}
+		entry := app.History{Id: len(releases) - i, Source: source}
+		// the Helm release revision counts all releases, also the ones no longer in the history
+		if version, _, _ := unstructured.NestedInt64(fields, "version"); version > 0 {
+			entry.Id = int(version)
+		}
+		entry.Revision, _, _ = unstructured.NestedString(fields, "chartVersion")
+		entry.DeployedAt, _, _ = unstructured.NestedString(fields, "lastDeployed")
+		if chart, _, _ := unstructured.NestedString(fields, "chartName"); chart != "" {
+			entry.Source.Chart = chart
+		}
+		history = append(history, entry)
+	}
+	return history
+}
+
+// setFluxStatus maps the Ready condition onto the health and the applied revision onto the sync status. Without a
+// history of its own, the applied revision becomes the only history entry, deployed when it last got ready.
+func setFluxStatus(item *app.Item, object unstructured.Unstructured, lastApplied string, revision string, sourceRevision string) {
+	item.Status.Health.Status = "Progressing"
+	var readyReason, readySince string
+	conditions, _, _ := unstructured.NestedSlice(object.Object, "status", "conditions")
+	for _, condition := range conditions {
+		fields, _, _ := unstructured.NestedStringMap(asMap(condition))
+		if fields["type"] != "Ready" {
+			continue
+		}
+		readyReason, readySince = fields["reason"], fields["lastTransitionTime"]
+		item.Status.Health.Message = fields["message"]
+		switch {
+		case fields["status"] == "True":
+			item.Status.Health.Status = "Healthy"
+		case fields["status"] == "False" && readyReason != "Progressing" && readyReason != "DependencyNotReady":
+			item.Status.Health.Status = "Degraded"
+			item.Status.Conditions = append(item.Status.Conditions, app.Condition{
+				Type: "ReconciliationError", Message: fields["message"], LastTransitionTime: readySince,
+			})
+		}
+	}
+	if suspended, _, _ := unstructured.NestedBool(object.Object, "spec", "suspend"); suspended {
+		item.Status.Health.Status = "Suspended"
+	}
+
+	lastAttempted, _, _ := unstructured.NestedString(object.Object, "status", "lastAttemptedRevision")
+	item.Status.Sync.Status = "Synced"
+	if lastApplied == "" || (lastAttempted != "" && lastAttempted != lastApplied) || (sourceRevision != "" && sourceRevision != lastApplied) {
+		item.Status.Sync.Status = "OutOfSync"
+	}
+	item.Status.Sync.Source = item.Spec.Source
+
+	if lastApplied != "" && len(item.Status.History) == 0 {
+		deployedAt := ""
+		if readyReason == "ReconciliationSucceeded" || item.Status.Health.Status == "Healthy" {
+			deployedAt = readySince
+		}
+		item.Status.History = []app.History{{Id: 1, Revision: revision, DeployedAt: deployedAt, Source: item.Spec.Source}}
+	}
+}
+
+// revisionOf returns the commit of a Flux revision, or the revision itself if it has none, i.e. for buckets.
+func revisionOf(revision string) string {
+	if match := commitPattern.FindStringSubmatch(revision); match != nil {
+		return match[1]
+	}
+	return revision
+}
+
+// inventoryResource parses an inventory entry of a Kustomization, its id is <namespace>_<name>_<group>_<kind>.
+func inventoryResource(entry map[string]any) (app.Resource, bool) {
+	id, _, _ := unstructured.NestedString(entry, "id")
+	version, _, _ := unstructured.NestedString(entry, "v")
+	parts := strings.Split(id, "_")
+	if len(parts) != 4 {
+		return app.Resource{}, false
+	}
+	return app.Resource{Namespace: parts[0], Name: parts[1], Group: parts[2], Kind: parts[3], Version: version}, true
+}
+
+// addWorkloadImages reads the images of the Deployments, StatefulSets and DaemonSets applied by each application,
+// which Argo CD reports itself but Flux doesn't.
+func (gateway *FluxGateway) addWorkloadImages(ctx context.Context, applications app.Applications) error {
+	indexes := map[string]int{}
+	for i, item := range applications.Items {
+		indexes[item.Kind+"/"+item.Metadata.Namespace+"/"+item.Metadata.Name] = i
+	}
+
+	for _, resource := range workloadResources {
+		for _, owner := range []struct{ kind, nameLabel, namespaceLabel string }{
+			{"Kustomization", kustomizeNameLabel, kustomizeNamespaceLabel},
+			{"HelmRelease", helmNameLabel, helmNamespaceLabel},
+		} {
+			list, err := gateway.kubernetes.dynamicClient.Resource(resource).List(ctx, metav1.ListOptions{LabelSelector: owner.nameLabel})
+			if err != nil {
+				return fmt.Errorf("could not list %s: %w", resource.Resource, err)
+			}
+			for _, workload := range list.Items {
+				labels := workload.GetLabels()
+				i, found := indexes[owner.kind+"/"+labels[owner.namespaceLabel]+"/"+labels[owner.nameLabel]]
+				if found {
+					addImages(&applications.Items[i], workload)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func addImages(item *app.Item, workload unstructured.Unstructured) {
+	for _, field := range []string{"initContainers", "containers"} {
+		containers, _, _ := unstructured.NestedSlice(workload.Object, "spec", "template", "spec", field)
+		for _, container := range containers {
+			image, _, _ := unstructured.NestedString(asMap(container), "image")
+			if image != "" && !containsString(item.Status.Summary.Images, image) {
+				item.Status.Summary.Images = append(item.Status.Summary.Images, image)
+			}
+		}
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,212 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package gateway
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"dashboard/internal/app"
+)
+
+func fluxObject(apiVersion string, kind string, name string, labels map[string]any, spec map[string]any, status map[string]any) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata":   map[string]any{"namespace": "flux-system", "name": name, "labels": labels},
+		"spec":       spec,
+		"status":     status,
+	}}
+}
+
+func ready(status string, reason string, message string) map[string]any {
+	return map[string]any{"type": "Ready", "status": status, "reason": reason, "message": message, "lastTransitionTime": "2023-10-20T08:00:00Z"}
+}
+
+// givenFluxCluster registers all resources the gateway may list, the fake client panics on unknown ones.
+func givenFluxCluster(t *testing.T, objects map[schema.GroupVersionResource][]*unstructured.Unstructured) *FluxGateway {
+	listKinds := map[schema.GroupVersionResource]string{}
+	resources := append(append([]schema.GroupVersionResource{}, kustomizationVersions...), helmReleaseVersions...)
+	for _, versions := range sourceVersions {
+		resources = append(resources, versions...)
+	}
+	resources = append(resources, workloadResources...)
+	for _, resource := range resources {
+		listKinds[resource] = "List"
+	}
+
+	dynamicClient := fake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listKinds)
+	for resource, list := range objects {
+		for _, object := range list {
+			if _, err := dynamicClient.Resource(resource).Namespace(object.GetNamespace()).Create(context.Background(), object, metav1.CreateOptions{}); err != nil {
+				t.Fatalf("Could not create %s: %v", resource.Resource, err)
+			}
+		}
+	}
+	return &FluxGateway{kubernetes: &ApplicationGateway{dynamicClient: dynamicClient}}
+}
+
+func givenFluxObjects(t *testing.T) *FluxGateway {
+	gitRepository := fluxObject("source.toolkit.fluxcd.io/v1", "GitRepository", "platform", nil,
+		map[string]any{"url": "https://github.com/eclipse-tractusx/platform", "ref": map[string]any{"branch": "main"}},
+		map[string]any{"artifact": map[string]any{"revision": "main@sha1:9f1c0d2e3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d"}})
+	helmRepository := fluxObject("source.toolkit.fluxcd.io/v1", "HelmRepository", "tractusx", nil,
+		map[string]any{"url": "https://eclipse-tractusx.github.io/charts/dev"}, nil)
+
+	kustomization := fluxObject("kustomize.toolkit.fluxcd.io/v1", "Kustomization", "portal", map[string]any{"team": "portal"},
+		map[string]any{"path": "./apps/portal", "targetNamespace": "product-portal", "sourceRef": map[string]any{"kind": "GitRepository", "name": "platform"}},
+		map[string]any{
+			"conditions":            []any{ready("True", "ReconciliationSucceeded", "Applied revision: main@sha1:9f1c")},
+			"lastAppliedRevision":   "main@sha1:9f1c0d2e3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d",
+			"lastAttemptedRevision": "main@sha1:9f1c0d2e3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d",
+			"inventory":             map[string]any{"entries": []any{map[string]any{"id": "product-portal_portal_networking.k8s.io_Ingress", "v": "v1"}}},
+		})
+	helmRelease := fluxObject("helm.toolkit.fluxcd.io/v2", "HelmRelease", "edc", nil,
+		map[string]any{"targetNamespace": "product-edc", "chart": map[string]any{"spec": map[string]any{
+			"chart": "tractusx-connector", "version": "0.6.x", "sourceRef": map[string]any{"kind": "HelmRepository", "name": "tractusx"},
+		}}},
+		map[string]any{
+			"conditions":            []any{ready("False", "UpgradeFailed", "Helm upgrade failed: timed out")},
+			"history":               []any{map[string]any{"chartVersion": "0.6.0"}},
+			"lastAttemptedRevision": "0.6.1",
+		})
+	deployment := fluxObject("apps/v1", "Deployment", "controlplane",
+		map[string]any{helmNameLabel: "edc", helmNamespaceLabel: "flux-system"},
+		map[string]any{"template": map[string]any{"spec": map[string]any{"containers": []any{
+			map[string]any{"image": "tractusx/edc-controlplane:0.6.0"},
+		}}}}, nil)
+	deployment.SetNamespace("product-edc")
+
+	return givenFluxCluster(t, map[schema.GroupVersionResource][]*unstructured.Unstructured{
+		sourceVersions["GitRepository"][0]:  {gitRepository},
+		sourceVersions["HelmRepository"][0]: {helmRepository},
+		kustomizationVersions[0]:            {kustomization},
+		helmReleaseVersions[0]:              {helmRelease},
+		workloadResources[0]:                {deployment},
+	})
+}
+
+func TestShouldMapKustomizationsAndHelmReleasesOntoApplications(t *testing.T) {
+	applications, err := givenFluxObjects(t).GetApplications(context.Background())
+	if err != nil || len(applications.Items) != 2 {
+		t.Fatalf("Flux objects not listed! \nexpected: %s \nGot: %v %v", "portal and edc", applications.Items, err)
+	}
+
+	portal := applications.Items[0]
+	if portal.Kind != "Kustomization" || portal.Spec.Destination.Namespace != "product-portal" || portal.Metadata.Labels["team"] != "portal" {
+		t.Errorf("Kustomization not mapped! \nexpected: %s \nGot: %v", "Kustomization to product-portal of team portal", portal)
+	}
+	expectedSource := app.Source{RepoUrl: "https://github.com/eclipse-tractusx/platform", Path: "./apps/portal", TargetRevision: "main"}
+	if portal.Spec.Source != expectedSource {
+		t.Errorf("Source of Kustomization not mapped! \nexpected: %v \nGot: %v", expectedSource, portal.Spec.Source)
+	}
+	if portal.Status.Health.Status != "Healthy" || portal.Status.Sync.Status != "Synced" {
+		t.Errorf("Status of Kustomization not mapped! \nexpected: %s \nGot: %v", "Healthy, Synced", portal.Status)
+	}
+	if len(portal.Status.History) != 1 || portal.Status.History[0].Revision != "9f1c0d2e3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d" ||
+		portal.Status.History[0].DeployedAt != "2023-10-20T08:00:00Z" {
+		t.Errorf("Applied revision not mapped! \nexpected: %s \nGot: %v", "commit deployed at 2023-10-20T08:00:00Z", portal.Status.History)
+	}
+	expectedResources := []app.Resource{{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress", Namespace: "product-portal", Name: "portal"}}
+	if !reflect.DeepEqual(portal.Status.Resources, expectedResources) {
+		t.Errorf("Inventory not mapped! \nexpected: %v \nGot: %v", expectedResources, portal.Status.Resources)
+	}
+
+	edc := applications.Items[1]
+	expectedChart := app.Source{RepoUrl: "https://eclipse-tractusx.github.io/charts/dev", Chart: "tractusx-connector", TargetRevision: "0.6.x"}
+	if edc.Spec.Source != expectedChart || edc.Status.History[0].Revision != "0.6.0" {
+		t.Errorf("Chart of HelmRelease not mapped! \nexpected: %v \nGot: %v %v", expectedChart, edc.Spec.Source, edc.Status.History)
+	}
+	if edc.Status.Health.Status != "Degraded" || edc.Status.Sync.Status != "OutOfSync" ||
+		len(edc.Status.Conditions) != 1 || !edc.Status.Conditions[0].IsError() {
+		t.Errorf("Failed upgrade not mapped! \nexpected: %s \nGot: %v", "Degraded, OutOfSync with an error condition", edc.Status)
+	}
+	if !reflect.DeepEqual(edc.Status.Summary.Images, []string{"tractusx/edc-controlplane:0.6.0"}) {
+		t.Errorf("Images of HelmRelease not read! \nexpected: %s \nGot: %v", "tractusx/edc-controlplane:0.6.0", edc.Status.Summary.Images)
+	}
+}
+
+func TestShouldReadTheReleaseHistoryOfUpgradedHelmReleases(t *testing.T) {
+	helmRelease := fluxObject("helm.toolkit.fluxcd.io/v2", "HelmRelease", "edc", nil,
+		map[string]any{"chart": map[string]any{"spec": map[string]any{"chart": "tractusx-connector", "version": "0.6.x"}}},
+		map[string]any{
+			// a later reconciliation moved the Ready condition, it is not when the release was deployed
+			"conditions": []any{ready("True", "UpgradeSucceeded", "Helm upgrade succeeded")},
+			"history": []any{
+				map[string]any{"chartName": "tractusx-connector", "chartVersion": "0.6.2", "status": "deployed", "version": int64(4), "lastDeployed": "2023-10-19T10:00:00Z"},
+				map[string]any{"chartName": "tractusx-connector", "chartVersion": "0.6.1", "status": "failed", "version": int64(3), "lastDeployed": "2023-10-18T10:00:00Z"},
+				map[string]any{"chartName": "tractusx-connector", "chartVersion": "0.6.0", "status": "superseded", "version": int64(2), "lastDeployed": "2023-10-17T10:00:00Z"},
+			},
+		})
+
+	item := helmReleaseToItem(*helmRelease, map[string]fluxSource{})
+
+	source := app.Source{Chart: "tractusx-connector", TargetRevision: "0.6.x"}
+	expected := []app.History{
+		{Id: 4, Revision: "0.6.2", DeployedAt: "2023-10-19T10:00:00Z", Source: source},
+		{Id: 2, Revision: "0.6.0", DeployedAt: "2023-10-17T10:00:00Z", Source: source},
+	}
+	if !reflect.DeepEqual(item.Status.History, expected) {
+		t.Errorf("Release history not mapped! \nexpected: %v \nGot: %v", expected, item.Status.History)
+	}
+	if item.DeployedVersion() != "0.6.2" || item.Status.Sync.Status != "Synced" {
+		t.Errorf("Upgrade not deployed! \nexpected: %s \nGot: %s %s", "0.6.2 Synced", item.DeployedVersion(), item.Status.Sync.Status)
+	}
+}
+
+func TestShouldFallBackToOlderFluxApiVersions(t *testing.T) {
+	kustomization := fluxObject("kustomize.toolkit.fluxcd.io/v1beta2", "Kustomization", "portal", nil,
+		map[string]any{"suspend": true}, nil)
+	gateway := givenFluxCluster(t, map[schema.GroupVersionResource][]*unstructured.Unstructured{kustomizationVersions[1]: {kustomization}})
+	dynamicClient := gateway.kubernetes.dynamicClient.(*fake.FakeDynamicClient)
+	dynamicClient.PrependReactor("list", "*", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		resource := action.GetResource()
+		if resource.Group != "apps" && (resource.Version == "v1" || resource.Version == "v2") {
+			return true, nil, apierrors.NewNotFound(resource.GroupResource(), "")
+		}
+		return false, nil, nil
+	})
+
+	applications, err := gateway.GetApplications(context.Background())
+	if err != nil || len(applications.Items) != 1 || applications.Items[0].Status.Health.Status != "Suspended" {
+		t.Errorf("Kustomization of older API version not listed! \nexpected: %s \nGot: %v %v", "suspended portal", applications.Items, err)
+	}
+}
+
+func TestShouldExtractCommitOfFluxRevisions(t *testing.T) {
+	for revision, expected := range map[string]string{
+		"main@sha1:9f1c0d2e3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d": "9f1c0d2e3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d",
+		"main/9f1c0d2e3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d":      "9f1c0d2e3a4b5c6d7e8f9a0b1c2d3e4f5a6b7c8d",
+		"sha256:4c3d": "sha256:4c3d",
+	} {
+		if actual := revisionOf(revision); actual != expected {
+			t.Errorf("Commit of %s not extracted! \nexpected: %s \nGot: %s", revision, expected, actual)
+		}
+	}
+}
@@ -22,81 +22,216 @@ package gateway
 import (
 	"context"
 	"dashboard/internal/app"
-	"encoding/json"
-	"flag"
+	"dashboard/internal/filter"
 	"fmt"
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	"log"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 )
 
 type ApplicationGateway struct {
-	clientset         *kubernetes.Clientset
-	ignoredNamespaces map[string]bool
+	clientset kubernetes.Interface
+	// dynamicClient reads custom resources like Gateway API routes
+	dynamicClient dynamic.Interface
+	options       Options
 }
 
-func NewApplicationGateway() *ApplicationGateway {
-	var clientSet *kubernetes.Clientset
-	if runsInCluster() {
-		clientSet = getClusterClientSet()
+// Options configure what the gateway reads besides the Argo CD applications.
+type Options struct {
+	// PodStatus adds the pods running for the applications, read from their destination namespaces
+	PodStatus bool
+	// Events adds the Warning events of the destination namespaces seen within the EventWindow, at most EventLimit
+	// per application
+	Events      bool
+	EventWindow time.Duration
+	EventLimit  int
+	// DiscoverUrls adds the hosts of the Ingresses, Gateway API HTTPRoutes and OpenShift Routes of the applications
+	// to their external URLs
+	DiscoverUrls bool
+	// Filter selects the applications returned; pods, events and URLs are only read for these
+	Filter *filter.Filter
+	// ApplicationNamespaces restricts listing applications to these namespaces, so namespaced roles suffice. By
+	// default, the applications of all namespaces are listed.
+	ApplicationNamespaces []string
+	// ToolNamespace is the namespace Argo CD or Flux is installed in, to read its version; empty for "argocd" or
+	// "flux-system"
+	ToolNamespace string
+	// PageSize is the number of applications listed per request, 0 lists all applications in a single request
+	PageSize int
+}
+
+// DefaultPageSize keeps the responses of large Argo CD installations at a few megabytes.
+const DefaultPageSize = 500
+
+func NewApplicationGateway(inCluster bool, kubeconfig string, options Options) (*ApplicationGateway, error) {
+	var config *rest.Config
+	var err error
+	if inCluster {
+		config, err = rest.InClusterConfig()
 	} else {
-		clientSet = getLocalClientSet()
+		config, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	return &ApplicationGateway{clientset: clientSet, ignoredNamespaces: ignoredNamespacesAsMap()}
-}
+	clientSet, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
 
-func (gateway *ApplicationGateway) GetApplications() app.Applications {
-	var applicationsResponse = app.Applications{}
+	return &ApplicationGateway{
+		clientset:     clientSet,
+		dynamicClient: dynamicClient,
+		options:       options,
+	}, nil
+}
 
-	d, err := gateway.clientset.RESTClient().Get().AbsPath("/apis/argoproj.io/v1alpha1/applications").DoRaw(context.TODO())
+func (gateway *ApplicationGateway) GetApplications(ctx context.Context) (app.Applications, error) {
+	applicationsResponse, err := gateway.listApplications(ctx)
 	if err != nil {
-		if statusError, ok := err.(*errors.StatusError); ok {
-			if statusError.Status().Code == 404 {
-				println("No applications found.")
-			} else {
-				fmt.Printf("Got an error from the k8s api: %v", string(d))
-				panic(statusError)
-			}
-		} else {
-			fmt.Printf("Got an error from the k8s api: %v", string(d))
-			panic(err)
-		}
-	} else {
-		if err := json.Unmarshal(d, &applicationsResponse); err != nil {
-			panic(err)
+		return applicationsResponse, err
+	}
+
+	transformApplicationsResponse(applicationsResponse)
+
+	if gateway.options.PodStatus {
+		gateway.addPods(ctx, applicationsResponse)
+	}
+	if gateway.options.Events {
+		gateway.addEvents(ctx, applicationsResponse)
+	}
+	if gateway.options.DiscoverUrls {
+		gateway.addDiscoveredUrls(ctx, applicationsResponse)
+	}
+
+	return applicationsResponse, nil
+}
+
+// listApplications lists the applications of all namespaces or, if configured, merges the applications of each of
+// the application namespaces. Applications not matching the filter are dropped while listing.
+func (gateway *ApplicationGateway) listApplications(ctx context.Context) (app.Applications, error) {
+	applications := app.Applications{Items: []app.Item{}}
+	if len(gateway.options.ApplicationNamespaces) == 0 {
+		err := gateway.listApplicationsAt(ctx, "/apis/argoproj.io/v1alpha1/applications", &applications)
+		return applications, err
+	}
+
+	for _, namespace := range gateway.options.ApplicationNamespaces {
+		if err := gateway.listApplicationsAt(ctx, "/apis/argoproj.io/v1alpha1/namespaces/"+namespace+"/applications", &applications); err != nil {
+			return applications, fmt.Errorf("could not list the applications of namespace %s: %w", namespace, err)
 		}
-		// TODO: Prints debug info on response data; Helpful for seeing what data is available; Should be set to debug
-		// fmt.Println(applicationsResponse)
 	}
+	return applications, nil
+}
 
-	transformApplicationsResponse(applicationsResponse, gateway.ignoredNamespaces)
+// listApplicationsAt appends the applications listed at the path page by page, decoding each page while it is read.
+func (gateway *ApplicationGateway) listApplicationsAt(ctx context.Context, path string, applications *app.Applications) error {
+	listed := len(applications.Items)
+	continueToken := ""
+	restarted := false
+	for {
+		request := gateway.clientset.Discovery().RESTClient().Get().AbsPath(path)
+		if gateway.options.PageSize > 0 {
+			request = request.Param("limit", strconv.Itoa(gateway.options.PageSize))
+		}
+		if continueToken != "" {
+			request = request.Param("continue", continueToken)
+		}
+
+		stream, err := request.Stream(ctx)
+		if errors.IsNotFound(err) && continueToken == "" {
+			log.Printf("No applications found at %s.", path)
+			return nil
+		}
+		if errors.IsResourceExpired(err) && !restarted {
+			// the applications changed too much while paging, start over once with a consistent list
+			log.Printf("Listing the applications at %s again, the list expired while paging.", path)
+			applications.Items = applications.Items[:listed]
+			continueToken, restarted = "", true
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("got an error from the k8s api: %w", err)
+		}
 
-	return applicationsResponse
+		continueToken, err = decodeApplications(stream, applications, gateway.options.Filter.Matches)
+		_ = stream.Close()
+		if err != nil {
+			return fmt.Errorf("could not parse applications: %w", err)
+		}
+		if continueToken == "" {
+			return nil
+		}
+	}
 }
 
-func (gateway *ApplicationGateway) ToolInfoAsHtml() string {
-	clusterVersion := getClusterVersion(gateway)
-	ignoredNamespaces := getIgnoredNamespacesRaw()
+func (gateway *ApplicationGateway) About(ctx context.Context) app.GatewayInfo {
+	source := "Kubernetes API"
+	if namespaces := gateway.options.ApplicationNamespaces; len(namespaces) > 0 {
+		source += ", namespaces " + strings.Join(namespaces, ", ")
+	}
+	return app.GatewayInfo{
+		Source:            source,
+		KubernetesVersion: getClusterVersion(gateway),
+		Tool:              "Argo CD",
+		ToolVersion:       gateway.deploymentVersion(ctx, gateway.toolNamespace("argocd"), "app.kubernetes.io/name=argocd-server"),
+	}
+}
 
-	return fmt.Sprintf("<ul><li>GitVersion / K8s cluster: %s</li><li>Ignored Namespaces: %s</li></ul>", clusterVersion, ignoredNamespaces)
+func (gateway *ApplicationGateway) toolNamespace(fallback string) string {
+	if gateway.options.ToolNamespace != "" {
+		return gateway.options.ToolNamespace
+	}
+	return fallback
 }
 
-func transformApplicationsResponse(applications app.Applications, ignoreNamespace map[string]bool) {
-	for i, item := range applications.Items {
-		applications.Items[i].IgnoreNamespace = false
-		if _, ok := ignoreNamespace[applications.Items[i].Spec.Destination.Namespace]; ok {
-			applications.Items[i].IgnoreNamespace = true
+// deploymentVersion reads the version of a tool from the version label of its deployments, or the image tag if the
+// label is missing like in the plain Argo CD manifests.
+func (gateway *ApplicationGateway) deploymentVersion(ctx context.Context, namespace string, selector string) string {
+	deployments, err := gateway.clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return "unknown"
+	}
+	for _, deployment := range deployments.Items {
+		if version := deployment.Labels["app.kubernetes.io/version"]; version != "" {
+			return version
+		}
+		for _, container := range deployment.Spec.Template.Spec.Containers {
+			if tag := imageTag(container.Image); tag != "" {
+				return tag
+			}
 		}
+	}
+	return "unknown"
+}
 
+func imageTag(image string) string {
+	image, _, _ = strings.Cut(image, "@")
+	// the registry may have a port, so only the last path segment has the tag
+	_, tag, _ := strings.Cut(image[strings.LastIndex(image, "/")+1:], ":")
+	return tag
+}
+
+func transformApplicationsResponse(applications app.Applications) {
+	for i, item := range applications.Items {
 		applications.Items[i].Status.Summary.LatestImage = false
 		applications.Items[i].Status.Summary.PostgresqlImageFound = false
 		for _, image := range item.Status.Summary.Images {
-			if strings.Contains(image, ":latest") || strings.Contains(image, ":main") {
+			if app.IsFloatingImageTag(image) {
 				applications.Items[i].Status.Summary.LatestImage = true
 			}
 
@@ -112,54 +247,19 @@ func transformApplicationsResponse(applications app.Applications, ignoreNamespac
 }
 
 func getClusterVersion(gateway *ApplicationGateway) string {
-	version, err := gateway.clientset.DiscoveryClient.ServerVersion()
-	versionString := version.GitVersion
+	version, err := gateway.clientset.Discovery().ServerVersion()
 	if err != nil {
-		versionString = "unknown"
+		return "unknown"
 	}
-	return versionString
+	return version.GitVersion
 }
 
-func getIgnoredNamespacesRaw() string {
-	return strings.TrimSpace(os.Getenv("IGNORE_NAMESPACE"))
-}
-
-func getClusterClientSet() *kubernetes.Clientset {
-	config, err := rest.InClusterConfig()
-	if err != nil {
-		panic(err.Error())
-	}
-	clientset, err := kubernetes.NewForConfig(config)
-	if err != nil {
-		panic(err.Error())
-	}
-	return clientset
-}
-
-func getLocalClientSet() *kubernetes.Clientset {
-	var kubeconfig *string
+// DefaultKubeconfig returns the kubeconfig location in the home directory of the current user, if there is one.
+func DefaultKubeconfig() string {
 	if home := homeDir(); home != "" {
-		kubeconfig = flag.String("kubeconfig", filepath.Join(home, ".kube", "config"), "(optional) absolute path to the kubeconfig file")
-	} else {
-		kubeconfig = flag.String("kubeconfig", "", "absolute path to the kubeconfig file")
+		return filepath.Join(home, ".kube", "config")
 	}
-	flag.Parse()
-
-	config, err := clientcmd.BuildConfigFromFlags("", *kubeconfig)
-	if err != nil {
-		panic(err.Error())
-	}
-	clientset, err := kubernetes.NewForConfig(config)
-	if err != nil {
-		panic(err.Error())
-	}
-	return clientset
-}
-
-func runsInCluster() bool {
-	inCluster := flag.Bool("in-cluster", false, "Specify if the code is running inside a cluster or from outside.")
-	flag.Parse()
-	return *inCluster
+	return ""
 }
 
 func homeDir() string {
@@ -168,17 +268,3 @@ func homeDir() string {
 	}
 	return os.Getenv("USERPROFILE") // windows
 }
-
-func ignoredNamespacesAsMap() map[string]bool {
-	var namespaces []string
-	ignoredNamespaces := getIgnoredNamespacesRaw()
-	result := make(map[string]bool)
-	if ignoredNamespaces != "" {
-		namespaces = strings.Split(ignoredNamespaces, ",")
-	}
-
-	for _, namespace := range namespaces {
-		result[namespace] = true
-	}
-	return result
-}
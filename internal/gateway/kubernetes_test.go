@@ -0,0 +1,316 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+)
+
+// givenApiServer answers the listed paths with the JSON, all other paths with 404.
+func givenApiServer(t *testing.T, responses map[string]string) *ApplicationGateway {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response, found := responses[r.URL.Path]
+		if !found {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(response))
+	}))
+	t.Cleanup(server.Close)
+
+	clientset, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &ApplicationGateway{clientset: clientset}
+}
+
+func applicationList(names ...string) string {
+	items := make([]string, 0, len(names))
+	for _, name := range names {
+		items = append(items, `{"metadata":{"name":"`+name+`","namespace":"argocd"}}`)
+	}
+	return `{"apiVersion":"argoproj.io/v1alpha1","kind":"ApplicationList","items":[` + strings.Join(items, ",") + `]}`
+}
+
+// givenPagedApiServer serves the applications in pages of the requested limit, the continue token is the offset of
+// the page. The continue token of the offset expiring is rejected once with 410 Gone. The requested queries are
+// recorded.
+func givenPagedApiServer(t testing.TB, items []string, expiring string) (*ApplicationGateway, *[]string) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		continueToken := r.URL.Query().Get("continue")
+		if continueToken != "" && continueToken == expiring {
+			expiring = ""
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusGone)
+			_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"Expired","code":410}`))
+			return
+		}
+
+		offset, _ := strconv.Atoi(continueToken)
+		end := len(items)
+		if limit, _ := strconv.Atoi(r.URL.Query().Get("limit")); limit > 0 && offset+limit < end {
+			end = offset + limit
+		}
+		next := ""
+		if end < len(items) {
+			next = strconv.Itoa(end)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"apiVersion":"argoproj.io/v1alpha1","kind":"ApplicationList","metadata":{"continue":%q},"items":[`, next)
+		for i := offset; i < end; i++ {
+			if i > offset {
+				_, _ = w.Write([]byte(","))
+			}
+			_, _ = w.Write([]byte(items[i]))
+		}
+		_, _ = w.Write([]byte("]}"))
+	}))
+	t.Cleanup(server.Close)
+
+	clientset, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL, QPS: -1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &ApplicationGateway{clientset: clientset}, &queries
+}
+
+func applicationItems(names ...string) []string {
+	items := make([]string, 0, len(names))
+	for _, name := range names {
+		items = append(items, `{"metadata":{"name":"`+name+`","namespace":"argocd"}}`)
+	}
+	return items
+}
+
+func namesOf(gateway *ApplicationGateway, t *testing.T) []string {
+	applications, err := gateway.GetApplications(context.Background())
+	if err != nil {
+		t.Fatalf("Could not get applications: %v", err)
+	}
+	names := []string{}
+	for _, item := range applications.Items {
+		names = append(names, item.Metadata.Name)
+	}
+	return names
+}
+
+func TestShouldListApplicationsOfAllNamespaces(t *testing.T) {
+	gateway := givenApiServer(t, map[string]string{
+		"/apis/argoproj.io/v1alpha1/applications": applicationList("edc", "portal"),
+	})
+
+	if names := namesOf(gateway, t); !reflect.DeepEqual(names, []string{"edc", "portal"}) {
+		t.Errorf("Applications not listed! \nexpected: %v \nGot: %v", []string{"edc", "portal"}, names)
+	}
+}
+
+func TestShouldMergeApplicationsOfTheConfiguredNamespaces(t *testing.T) {
+	gateway := givenApiServer(t, map[string]string{
+		"/apis/argoproj.io/v1alpha1/applications":                   applicationList("cluster-wide"),
+		"/apis/argoproj.io/v1alpha1/namespaces/argocd/applications": applicationList("edc"),
+		"/apis/argoproj.io/v1alpha1/namespaces/team-a/applications": applicationList("portal", "bpdm"),
+	})
+	gateway.options.ApplicationNamespaces = []string{"argocd", "team-a", "team-b"}
+
+	expected := []string{"edc", "portal", "bpdm"}
+	if names := namesOf(gateway, t); !reflect.DeepEqual(names, expected) {
+		t.Errorf("Applications of the namespaces not merged! \nexpected: %v \nGot: %v", expected, names)
+	}
+}
+
+func TestShouldNameTheNamespaceApplicationsCouldNotBeListedIn(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"Forbidden","code":403}`))
+	}))
+	defer server.Close()
+	clientset, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	gateway := &ApplicationGateway{clientset: clientset, options: Options{ApplicationNamespaces: []string{"team-a"}}}
+
+	if _, err := gateway.GetApplications(context.Background()); err == nil || !strings.Contains(err.Error(), "namespace team-a") {
+		t.Errorf("Expected an error naming the namespace! Got: %v", err)
+	}
+}
+
+func TestShouldListApplicationsPageByPage(t *testing.T) {
+	gateway, queries := givenPagedApiServer(t, applicationItems("edc", "portal", "bpdm"), "")
+	gateway.options.PageSize = 2
+
+	if names := namesOf(gateway, t); !reflect.DeepEqual(names, []string{"edc", "portal", "bpdm"}) {
+		t.Errorf("Applications of all pages not listed! \nexpected: %v \nGot: %v", []string{"edc", "portal", "bpdm"}, names)
+	}
+	if expected := []string{"limit=2", "continue=2&limit=2"}; !reflect.DeepEqual(*queries, expected) {
+		t.Errorf("Applications not requested page by page! \nexpected: %v \nGot: %v", expected, *queries)
+	}
+}
+
+func TestShouldStartOverIfTheListExpiredWhilePaging(t *testing.T) {
+	gateway, queries := givenPagedApiServer(t, applicationItems("edc", "portal", "bpdm"), "2")
+	gateway.options.PageSize = 2
+
+	if names := namesOf(gateway, t); !reflect.DeepEqual(names, []string{"edc", "portal", "bpdm"}) {
+		t.Errorf("Applications not listed again! \nexpected: %v \nGot: %v", []string{"edc", "portal", "bpdm"}, names)
+	}
+	if len(*queries) != 4 {
+		t.Errorf("Expected the list to be started over once! Got: %v", *queries)
+	}
+}
+
+func TestShouldDecodeApplicationsSkippingUnknownFields(t *testing.T) {
+	items := []string{
+		`{"metadata":{"name":"edc","namespace":"argocd","managedFields":[{"manager":"argocd-controller"}]},` +
+			`"spec":{"destination":{"namespace":"product-edc"}},"status":{"operationState":{"phase":"Succeeded"},"summary":{"images":["tractusx/edc:0.6.0"]}}}`,
+	}
+	gateway, _ := givenPagedApiServer(t, items, "")
+
+	applications, err := gateway.GetApplications(context.Background())
+	if err != nil || len(applications.Items) != 1 || applications.Items[0].Spec.Destination.Namespace != "product-edc" ||
+		applications.Items[0].Status.Summary.Images[0] != "tractusx/edc:0.6.0" || applications.Kind != "ApplicationList" {
+		t.Errorf("Application not decoded! \nexpected: %s \nGot: %v %v", "edc in product-edc", applications, err)
+	}
+}
+
+// benchmarkApplication resembles an application of Argo CD with a few dozen managed resources.
+func benchmarkApplication(i int) string {
+	resources := make([]string, 0, 40)
+	for r := 0; r < 40; r++ {
+		resources = append(resources, fmt.Sprintf(`{"group":"apps","version":"v1","kind":"Deployment","namespace":"product-%d","name":"component-%d","status":"Synced","health":{"status":"Healthy"}}`, i, r))
+	}
+	return fmt.Sprintf(`{"apiVersion":"argoproj.io/v1alpha1","kind":"Application",`+
+		`"metadata":{"name":"application-%d","namespace":"argocd","labels":{"team":"team-%d"},"managedFields":[{"manager":"argocd-application-controller","fieldsV1":{"f:status":{"f:resources":{},"f:summary":{}}}}]},`+
+		`"spec":{"destination":{"namespace":"product-%d","server":"https://kubernetes.default.svc"},"project":"default","source":{"repoURL":"https://eclipse-tractusx.github.io/charts/dev","chart":"tractusx-connector","targetRevision":"0.6.0"}},`+
+		`"status":{"health":{"status":"Healthy"},"sync":{"status":"Synced"},"summary":{"images":["tractusx/edc-controlplane:0.6.0","tractusx/edc-dataplane:0.6.0"]},`+
+		`"operationState":{"phase":"Succeeded","message":"successfully synced (all tasks run)","syncResult":{"resources":[%s]}},"resources":[%s]}}`,
+		i, i%20, i, strings.Join(resources, ","), strings.Join(resources, ","))
+}
+
+// BenchmarkListApplications reports the peak heap in use while listing, sampled every millisecond, next to the heap
+// retained by the listed applications. The difference is what decoding holds on top of the result.
+func BenchmarkListApplications(b *testing.B) {
+	for _, count := range []int{1000, 5000} {
+		items := make([]string, 0, count)
+		for i := 0; i < count; i++ {
+			items = append(items, benchmarkApplication(i))
+		}
+
+		for _, pageSize := range []int{0, DefaultPageSize} {
+			b.Run(fmt.Sprintf("applications=%d/page-size=%d", count, pageSize), func(b *testing.B) {
+				gateway, _ := givenPagedApiServer(b, items, "")
+				gateway.options.PageSize = pageSize
+				var peak, retained uint64
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					runtime.GC()
+					stop := sampleHeap()
+					applications, err := gateway.GetApplications(context.Background())
+					peak = max(peak, stop())
+					if err != nil {
+						b.Fatal(err)
+					}
+					runtime.GC()
+					retained = max(retained, heapInUse())
+					runtime.KeepAlive(applications)
+				}
+				b.ReportMetric(float64(peak)/(1<<20), "peak-heap-MB")
+				b.ReportMetric(float64(retained)/(1<<20), "retained-heap-MB")
+			})
+		}
+	}
+}
+
+// sampleHeap samples the heap in use until the returned function is called, which returns the maximum.
+func sampleHeap() func() uint64 {
+	done := make(chan struct{})
+	peak := make(chan uint64)
+	go func() {
+		ticker := time.NewTicker(time.Millisecond)
+		defer ticker.Stop()
+		var maximum uint64
+		for {
+			maximum = max(maximum, heapInUse())
+			select {
+			case <-done:
+				peak <- maximum
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return func() uint64 {
+		close(done)
+		return <-peak
+	}
+}
+
+func heapInUse() uint64 {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return stats.HeapInuse
+}
+
+func TestShouldReadVersionsOfClusterAndArgoCd(t *testing.T) {
+	for expected, labels := range map[string]map[string]string{
+		"v2.8.4": {"app.kubernetes.io/name": "argocd-server"},
+		"v2.8.5": {"app.kubernetes.io/name": "argocd-server", "app.kubernetes.io/version": "v2.8.5"},
+	} {
+		clientset := fake.NewSimpleClientset(&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "argocd", Name: "argocd-server", Labels: labels},
+			Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "argocd-server", Image: "registry.example.org:5000/argoproj/argocd:v2.8.4"},
+			}}}},
+		})
+		clientset.Discovery().(*fakediscovery.FakeDiscovery).FakedServerVersion = &version.Info{GitVersion: "v1.28.2"}
+		gateway := &ApplicationGateway{clientset: clientset}
+
+		info := gateway.About(context.Background())
+
+		if info.KubernetesVersion != "v1.28.2" || info.Tool != "Argo CD" || info.ToolVersion != expected {
+			t.Errorf("Versions not read! \nexpected: %s \nGot: %v", "v1.28.2, Argo CD "+expected, info)
+		}
+	}
+}
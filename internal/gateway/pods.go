@@ -0,0 +1,197 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package gateway
+
+import (
+	"context"
+	"log"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"dashboard/internal/app"
+)
+
+const (
+	trackingIdAnnotation = "argocd.argoproj.io/tracking-id"
+	instanceLabel        = "app.kubernetes.io/instance"
+)
+
+// addPods reads the pods of the destination namespaces and assigns them to the applications deploying them. Errors
+// are only logged, since the applications are still worth showing without their pods.
+func (gateway *ApplicationGateway) addPods(ctx context.Context, applications app.Applications) {
+	byNamespace, namespaces := applicationsByNamespace(applications)
+	for _, namespace := range namespaces {
+		pods, err := gateway.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			log.Printf("Could not list pods in namespace %s: %v", namespace, err)
+			continue
+		}
+
+		indexes := byNamespace[namespace]
+		for _, i := range indexes {
+			applications.Items[i].Pods = &app.PodSummary{}
+		}
+		for _, pod := range pods.Items {
+			if i, found := owningApplication(pod, applications, indexes); found {
+				addPod(applications.Items[i].Pods, pod, applications.Items[i].Status.Summary.Images)
+			}
+		}
+	}
+}
+
+// applicationsByNamespace groups the indexes of the applications by their destination namespace. The namespaces are
+// returned sorted.
+func applicationsByNamespace(applications app.Applications) (map[string][]int, []string) {
+	byNamespace := map[string][]int{}
+	for i, item := range applications.Items {
+		if item.Spec.Destination.Namespace != "" {
+			byNamespace[item.Spec.Destination.Namespace] = append(byNamespace[item.Spec.Destination.Namespace], i)
+		}
+	}
+
+	namespaces := make([]string, 0, len(byNamespace))
+	for namespace := range byNamespace {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	return byNamespace, namespaces
+}
+
+// owningApplication finds the application a pod belongs to through the tracking annotation or the instance label
+// Argo CD sets, otherwise through the managed resource owning it. Argo CD only tracks the resources it manages, so
+// pods often carry neither. Untracked pods are assigned to the only application of the namespace, if there is just one.
+func owningApplication(pod corev1.Pod, applications app.Applications, indexes []int) (int, bool) {
+	owner := pod.Labels[instanceLabel]
+	if trackingId, found := pod.Annotations[trackingIdAnnotation]; found {
+		owner, _, _ = strings.Cut(trackingId, ":")
+	}
+
+	for _, i := range indexes {
+		metadata := applications.Items[i].Metadata
+		// applications outside the namespace of Argo CD are tracked as <namespace>_<name>
+		if owner != "" && (metadata.Name == owner || metadata.Namespace+"_"+metadata.Name == owner) {
+			return i, true
+		}
+	}
+	if kind, name, found := workloadOf(pod); found {
+		for _, i := range indexes {
+			for _, resource := range applications.Items[i].Status.Resources {
+				if resource.Kind == kind && resource.Name == name && (resource.Namespace == "" || resource.Namespace == pod.Namespace) {
+					return i, true
+				}
+			}
+		}
+		// jobs created by a CronJob are named <cronjob>-<scheduled time>
+		if kind == "Job" {
+			for _, i := range indexes {
+				for _, resource := range applications.Items[i].Status.Resources {
+					if resource.Kind == "CronJob" && strings.HasPrefix(name, resource.Name+"-") {
+						return i, true
+					}
+				}
+			}
+		}
+	}
+	if len(indexes) == 1 {
+		return indexes[0], true
+	}
+	return 0, false
+}
+
+// workloadOf returns the kind and name of the workload controlling the pod. ReplicaSets are named after their
+// Deployment and the template hash the pod is labeled with, so the Deployment is found without reading them.
+func workloadOf(pod corev1.Pod) (string, string, bool) {
+	for _, reference := range pod.OwnerReferences {
+		if reference.Controller == nil || !*reference.Controller {
+			continue
+		}
+		if hash := pod.Labels[appsv1.DefaultDeploymentUniqueLabelKey]; reference.Kind == "ReplicaSet" && hash != "" {
+			if deployment, found := strings.CutSuffix(reference.Name, "-"+hash); found {
+				return "Deployment", deployment, true
+			}
+		}
+		return reference.Kind, reference.Name, true
+	}
+	return "", "", false
+}
+
+func addPod(summary *app.PodSummary, pod corev1.Pod, listedImages []string) {
+	// completed pods, i.e. of jobs, are neither running nor a problem
+	if pod.Status.Phase == corev1.PodSucceeded {
+		return
+	}
+
+	summary.Total++
+	if isPodReady(pod) {
+		summary.Ready++
+	}
+
+	for _, status := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		summary.Restarts += int(status.RestartCount)
+
+		container := app.Container{
+			Pod:          pod.Name,
+			Name:         status.Name,
+			Image:        status.Image,
+			ImageId:      status.ImageID,
+			Ready:        status.Ready,
+			RestartCount: int(status.RestartCount),
+			Listed:       isListed(status.Image, listedImages),
+		}
+		switch {
+		case status.State.Waiting != nil:
+			container.State, container.Reason = "Waiting", status.State.Waiting.Reason
+		case status.State.Terminated != nil:
+			container.State, container.Reason = "Terminated", status.State.Terminated.Reason
+		default:
+			container.State = "Running"
+		}
+		summary.Containers = append(summary.Containers, container)
+	}
+}
+
+func isPodReady(pod corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// isListed compares the image of a container with the images Argo CD reports. The container runtime adds the default
+// registry to images from Docker Hub, which the manifests usually leave out.
+func isListed(image string, listedImages []string) bool {
+	for _, listed := range listedImages {
+		if normalizeImage(listed) == normalizeImage(image) {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeImage(image string) string {
+	image = strings.TrimPrefix(image, "docker.io/")
+	return strings.TrimPrefix(image, "library/")
+}
@@ -0,0 +1,169 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package gateway
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"dashboard/internal/app"
+)
+
+func pod(namespace string, name string, labels map[string]string, ready bool, containers ...corev1.ContainerStatus) *corev1.Pod {
+	readyStatus := corev1.ConditionFalse
+	if ready {
+		readyStatus = corev1.ConditionTrue
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: labels},
+		Status: corev1.PodStatus{
+			Phase:             corev1.PodRunning,
+			Conditions:        []corev1.PodCondition{{Type: corev1.PodReady, Status: readyStatus}},
+			ContainerStatuses: containers,
+		},
+	}
+}
+
+func application(name string, namespace string, images ...string) app.Item {
+	item := app.Item{Metadata: app.Metadata{Name: name, Namespace: "argocd"}}
+	item.Spec.Destination.Namespace = namespace
+	item.Status.Summary.Images = images
+	return item
+}
+
+func TestShouldAddPodsToApplicationsOfTheirNamespace(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		pod("product-edc", "controlplane-1", map[string]string{instanceLabel: "edc"}, true, corev1.ContainerStatus{
+			Name: "controlplane", Image: "docker.io/tractusx/edc-controlplane:0.6.0", Ready: true, RestartCount: 1,
+			ImageID: "docker.io/tractusx/edc-controlplane@sha256:4c3d", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+		}),
+		pod("product-edc", "dataplane-1", map[string]string{instanceLabel: "edc"}, false, corev1.ContainerStatus{
+			Name: "dataplane", Image: "tractusx/edc-dataplane:0.6.1", RestartCount: 7,
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+		}),
+		pod("product-edc", "vault-0", map[string]string{instanceLabel: "vault"}, true),
+		pod("product-irs", "irs-1", nil, true),
+	)
+	applications := app.Applications{Items: []app.Item{
+		application("edc", "product-edc", "tractusx/edc-controlplane:0.6.0", "tractusx/edc-dataplane:0.6.0"),
+		application("vault", "product-edc"),
+		application("irs", "product-irs"),
+	}}
+
+	gateway := &ApplicationGateway{clientset: clientset, options: Options{PodStatus: true}}
+	gateway.addPods(context.Background(), applications)
+
+	edc := applications.Items[0].Pods
+	if edc == nil || edc.Total != 2 || edc.Ready != 1 || edc.Restarts != 8 {
+		t.Fatalf("Pods of edc not summarized correctly! \nexpected: %s \nGot: %v", "2 pods, 1 ready, 8 restarts", edc)
+	}
+	if problems := edc.Problems(); len(problems) != 1 || problems[0].Reason != "CrashLoopBackOff" || problems[0].Pod != "dataplane-1" {
+		t.Errorf("Crashing container not reported! \nexpected: %s \nGot: %v", "dataplane-1 CrashLoopBackOff", problems)
+	}
+	if unlisted := edc.UnlistedImages(); !reflect.DeepEqual(unlisted, []string{"tractusx/edc-dataplane:0.6.1"}) {
+		t.Errorf("Image not reported by Argo CD not detected! \nexpected: %v \nGot: %v", []string{"tractusx/edc-dataplane:0.6.1"}, unlisted)
+	}
+	if digest := edc.Containers[0].Digest(); digest != "sha256:4c3d" {
+		t.Errorf("Running digest not read! \nexpected: %s \nGot: %s", "sha256:4c3d", digest)
+	}
+	if vault := applications.Items[1].Pods; vault == nil || vault.Total != 1 {
+		t.Errorf("Pods not assigned by instance label! \nexpected: %d \nGot: %v", 1, vault)
+	}
+	if irs := applications.Items[2].Pods; irs == nil || irs.Total != 1 {
+		t.Errorf("Untracked pod not assigned to only application of the namespace! \nexpected: %d \nGot: %v", 1, irs)
+	}
+}
+
+func TestShouldAssignPodsByTrackingAnnotation(t *testing.T) {
+	tracked := pod("product-edc", "controlplane-1", map[string]string{instanceLabel: "release-name"}, true)
+	tracked.Annotations = map[string]string{trackingIdAnnotation: "edc:apps/Deployment:product-edc/controlplane"}
+	applications := app.Applications{Items: []app.Item{application("edc", "product-edc"), application("vault", "product-edc")}}
+
+	gateway := &ApplicationGateway{clientset: fake.NewSimpleClientset(tracked)}
+	gateway.addPods(context.Background(), applications)
+
+	if edc := applications.Items[0].Pods; edc == nil || edc.Total != 1 {
+		t.Errorf("Pod not assigned by tracking annotation! \nexpected: %d \nGot: %v", 1, edc)
+	}
+}
+
+func TestShouldAssignPodsByTheWorkloadOwningThem(t *testing.T) {
+	owned := func(name string, kind string, owner string, labels map[string]string) *corev1.Pod {
+		controller := true
+		result := pod("product-edc", name, labels, true)
+		result.OwnerReferences = []metav1.OwnerReference{{Kind: kind, Name: owner, Controller: &controller}}
+		return result
+	}
+	clientset := fake.NewSimpleClientset(
+		owned("controlplane-7d9f8b6c5-x2k4q", "ReplicaSet", "controlplane-7d9f8b6c5", map[string]string{"pod-template-hash": "7d9f8b6c5"}),
+		owned("vault-0", "StatefulSet", "vault", nil),
+		owned("vault-backup-28312440-8zq2m", "Job", "vault-backup-28312440", nil),
+		pod("product-edc", "unknown-1", nil, true),
+	)
+	edc, vault := application("edc", "product-edc"), application("vault", "product-edc")
+	edc.Status.Resources = []app.Resource{{Group: "apps", Kind: "Deployment", Namespace: "product-edc", Name: "controlplane"}}
+	vault.Status.Resources = []app.Resource{
+		{Group: "apps", Kind: "StatefulSet", Namespace: "product-edc", Name: "vault"},
+		{Group: "batch", Kind: "CronJob", Namespace: "product-edc", Name: "vault-backup"},
+	}
+	applications := app.Applications{Items: []app.Item{edc, vault}}
+
+	gateway := &ApplicationGateway{clientset: clientset}
+	gateway.addPods(context.Background(), applications)
+
+	if pods := applications.Items[0].Pods; pods == nil || pods.Total != 1 {
+		t.Errorf("Pod of Deployment not assigned! \nexpected: %d \nGot: %v", 1, pods)
+	}
+	if pods := applications.Items[1].Pods; pods == nil || pods.Total != 2 {
+		t.Errorf("Pods of StatefulSet and CronJob not assigned! \nexpected: %d \nGot: %v", 2, pods)
+	}
+}
+
+func TestShouldAssignPodsOfApplicationsInAnyNamespace(t *testing.T) {
+	tracked := pod("product-edc", "controlplane-1", map[string]string{instanceLabel: "team-edc_edc"}, true)
+	edc := application("edc", "product-edc")
+	edc.Metadata.Namespace = "team-edc"
+	applications := app.Applications{Items: []app.Item{edc, application("vault", "product-edc")}}
+
+	gateway := &ApplicationGateway{clientset: fake.NewSimpleClientset(tracked)}
+	gateway.addPods(context.Background(), applications)
+
+	if pods := applications.Items[0].Pods; pods == nil || pods.Total != 1 {
+		t.Errorf("Pod not assigned by <namespace>_<name> instance! \nexpected: %d \nGot: %v", 1, pods)
+	}
+}
+
+func TestShouldSkipCompletedPods(t *testing.T) {
+	completed := pod("product-irs", "migration-1", nil, false)
+	completed.Status.Phase = corev1.PodSucceeded
+	applications := app.Applications{Items: []app.Item{application("irs", "product-irs")}}
+
+	gateway := &ApplicationGateway{clientset: fake.NewSimpleClientset(completed)}
+	gateway.addPods(context.Background(), applications)
+
+	if irs := applications.Items[0].Pods; irs == nil || irs.Total != 0 {
+		t.Errorf("Completed pods should not be counted! \nexpected: %d \nGot: %v", 0, irs)
+	}
+}
@@ -0,0 +1,229 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package gateway
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"dashboard/internal/app"
+)
+
+// urlSource reads the URLs of a kind of object, i.e. of Ingresses.
+type urlSource struct {
+	resource string
+	// defaultVersion is used if Argo CD does not report the version of the object
+	defaultVersion string
+	urls           func(ctx context.Context, gateway *ApplicationGateway, object *unstructured.Unstructured, version string) []string
+}
+
+// urlSources by group and kind
+var urlSources = map[schema.GroupKind]urlSource{
+	{Group: "networking.k8s.io", Kind: "Ingress"}:           {resource: "ingresses", defaultVersion: "v1", urls: ingressUrls},
+	{Group: "gateway.networking.k8s.io", Kind: "HTTPRoute"}: {resource: "httproutes", defaultVersion: "v1", urls: httpRouteUrls},
+	{Group: "route.openshift.io", Kind: "Route"}:            {resource: "routes", defaultVersion: "v1", urls: openshiftRouteUrls},
+}
+
+// addDiscoveredUrls reads the routing objects managed by the applications and merges their URLs into the external
+// URLs reported by Argo CD. Objects that can't be read are skipped, since Argo's URLs are still worth showing.
+func (gateway *ApplicationGateway) addDiscoveredUrls(ctx context.Context, applications app.Applications) {
+	for i, item := range applications.Items {
+		var discovered []string
+		for _, resource := range item.Status.Resources {
+			source, found := urlSources[schema.GroupKind{Group: resource.Group, Kind: resource.Kind}]
+			if !found {
+				continue
+			}
+			version := resource.Version
+			if version == "" {
+				version = source.defaultVersion
+			}
+
+			resourceClient := gateway.dynamicClient.Resource(schema.GroupVersionResource{Group: resource.Group, Version: version, Resource: source.resource})
+			object, err := resourceClient.Namespace(resource.Namespace).Get(ctx, resource.Name, metav1.GetOptions{})
+			if err != nil {
+				log.Printf("Could not read %s %s/%s: %v", resource.Kind, resource.Namespace, resource.Name, err)
+				continue
+			}
+			discovered = append(discovered, source.urls(ctx, gateway, object, version)...)
+		}
+
+		applications.Items[i].Status.Summary.ExternalUrls = mergeUrls(item.Status.Summary.ExternalUrls, discovered)
+	}
+}
+
+func ingressUrls(_ context.Context, _ *ApplicationGateway, object *unstructured.Unstructured, _ string) []string {
+	var tlsHosts []string
+	tlsEntries, _, _ := unstructured.NestedSlice(object.Object, "spec", "tls")
+	for _, entry := range tlsEntries {
+		hosts, _, _ := unstructured.NestedStringSlice(asMap(entry), "hosts")
+		tlsHosts = append(tlsHosts, hosts...)
+	}
+
+	var urls []string
+	rules, _, _ := unstructured.NestedSlice(object.Object, "spec", "rules")
+	for _, rule := range rules {
+		host, _, _ := unstructured.NestedString(asMap(rule), "host")
+		paths, _, _ := unstructured.NestedSlice(asMap(rule), "http", "paths")
+		path := ""
+		if len(paths) > 0 {
+			path, _, _ = unstructured.NestedString(asMap(paths[0]), "path")
+		}
+		urls = appendUrl(urls, host, path, matchesAnyHost(host, tlsHosts))
+	}
+	return urls
+}
+
+// httpRouteUrls uses TLS if a listener of a parent Gateway the route attaches to terminates HTTPS.
+func httpRouteUrls(ctx context.Context, gateway *ApplicationGateway, object *unstructured.Unstructured, version string) []string {
+	hostnames, _, _ := unstructured.NestedStringSlice(object.Object, "spec", "hostnames")
+
+	path := ""
+	rules, _, _ := unstructured.NestedSlice(object.Object, "spec", "rules")
+	if len(rules) > 0 {
+		matches, _, _ := unstructured.NestedSlice(asMap(rules[0]), "matches")
+		if len(matches) > 0 {
+			path, _, _ = unstructured.NestedString(asMap(matches[0]), "path", "value")
+		}
+	}
+
+	var httpsHosts []string
+	parentRefs, _, _ := unstructured.NestedSlice(object.Object, "spec", "parentRefs")
+	for _, parentRef := range parentRefs {
+		httpsHosts = append(httpsHosts, gateway.httpsListenerHosts(ctx, asMap(parentRef), object.GetNamespace(), version)...)
+	}
+
+	var urls []string
+	for _, hostname := range hostnames {
+		urls = appendUrl(urls, hostname, path, matchesAnyHost(hostname, httpsHosts))
+	}
+	return urls
+}
+
+// httpsListenerHosts returns the hostnames of the HTTPS listeners of the referenced Gateway, "*" for listeners
+// without a hostname.
+func (gateway *ApplicationGateway) httpsListenerHosts(ctx context.Context, parentRef map[string]any, routeNamespace string, version string) []string {
+	if kind, _, _ := unstructured.NestedString(parentRef, "kind"); kind != "" && kind != "Gateway" {
+		return nil
+	}
+	name, _, _ := unstructured.NestedString(parentRef, "name")
+	namespace, _, _ := unstructured.NestedString(parentRef, "namespace")
+	if namespace == "" {
+		namespace = routeNamespace
+	}
+	sectionName, _, _ := unstructured.NestedString(parentRef, "sectionName")
+
+	gatewayResource := schema.GroupVersionResource{Group: "gateway.networking.k8s.io", Version: version, Resource: "gateways"}
+	parent, err := gateway.dynamicClient.Resource(gatewayResource).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		log.Printf("Could not read Gateway %s/%s: %v", namespace, name, err)
+		return nil
+	}
+
+	var hosts []string
+	listeners, _, _ := unstructured.NestedSlice(parent.Object, "spec", "listeners")
+	for _, listener := range listeners {
+		listenerName, _, _ := unstructured.NestedString(asMap(listener), "name")
+		protocol, _, _ := unstructured.NestedString(asMap(listener), "protocol")
+		if protocol != "HTTPS" || (sectionName != "" && sectionName != listenerName) {
+			continue
+		}
+		hostname, _, _ := unstructured.NestedString(asMap(listener), "hostname")
+		if hostname == "" {
+			hostname = "*"
+		}
+		hosts = append(hosts, hostname)
+	}
+	return hosts
+}
+
+func openshiftRouteUrls(_ context.Context, _ *ApplicationGateway, object *unstructured.Unstructured, _ string) []string {
+	host, _, _ := unstructured.NestedString(object.Object, "spec", "host")
+	path, _, _ := unstructured.NestedString(object.Object, "spec", "path")
+	_, tls, _ := unstructured.NestedMap(object.Object, "spec", "tls")
+	return appendUrl(nil, host, path, tls)
+}
+
+// appendUrl adds the URL of the host, leaving out hosts that aren't reachable by name like wildcards.
+func appendUrl(urls []string, host string, path string, tls bool) []string {
+	if host == "" || strings.Contains(host, "*") {
+		return urls
+	}
+	scheme := "http://"
+	if tls {
+		scheme = "https://"
+	}
+	if path == "/" {
+		path = ""
+	}
+	return append(urls, scheme+host+path)
+}
+
+// matchesAnyHost supports wildcards like *.example.org, which match a single label, and "*" for any host.
+func matchesAnyHost(host string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if pattern == "*" || pattern == host {
+			return true
+		}
+		if suffix, found := strings.CutPrefix(pattern, "*."); found {
+			if label, rest, ok := strings.Cut(host, "."); ok && label != "" && rest == suffix {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// mergeUrls adds the discovered URLs to the ones reported by Argo CD, comparing them without scheme and trailing
+// slash. Reported URLs are upgraded to https if the discovered URL uses TLS.
+func mergeUrls(reported []string, discovered []string) []string {
+	merged := append([]string(nil), reported...)
+	for _, url := range discovered {
+		duplicate := false
+		for i, existing := range merged {
+			if withoutScheme(existing) == withoutScheme(url) {
+				duplicate = true
+				if strings.HasPrefix(url, "https://") {
+					merged[i] = "https://" + strings.TrimPrefix(existing, "http://")
+				}
+				break
+			}
+		}
+		if !duplicate {
+			merged = append(merged, url)
+		}
+	}
+	return merged
+}
+
+func withoutScheme(url string) string {
+	url = strings.TrimPrefix(strings.TrimPrefix(url, "https://"), "http://")
+	return strings.TrimSuffix(url, "/")
+}
+
+func asMap(value any) map[string]any {
+	result, _ := value.(map[string]any)
+	return result
+}
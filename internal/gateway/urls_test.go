@@ -0,0 +1,152 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package gateway
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic/fake"
+
+	"dashboard/internal/app"
+)
+
+func object(apiVersion string, kind string, namespace string, name string, spec map[string]any) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata":   map[string]any{"namespace": namespace, "name": name},
+		"spec":       spec,
+	}}
+}
+
+// givenObjects creates the objects through the resource, the fake client guesses wrong plurals like "gatewaies".
+func givenObjects(t *testing.T, objects map[string]*unstructured.Unstructured) *fake.FakeDynamicClient {
+	dynamicClient := fake.NewSimpleDynamicClient(runtime.NewScheme())
+	for resource, object := range objects {
+		gvr := object.GroupVersionKind().GroupVersion().WithResource(resource)
+		if _, err := dynamicClient.Resource(gvr).Namespace(object.GetNamespace()).Create(context.Background(), object, metav1.CreateOptions{}); err != nil {
+			t.Fatalf("Could not create %s: %v", resource, err)
+		}
+	}
+	return dynamicClient
+}
+
+func managing(item app.Item, resources ...app.Resource) app.Item {
+	item.Status.Resources = resources
+	return item
+}
+
+func TestShouldDiscoverUrlsFromIngressesHttpRoutesAndOpenshiftRoutes(t *testing.T) {
+	dynamicClient := givenObjects(t, map[string]*unstructured.Unstructured{
+		"ingresses": object("networking.k8s.io/v1", "Ingress", "product-edc", "controlplane", map[string]any{
+			"tls": []any{map[string]any{"hosts": []any{"*.example.org"}}},
+			"rules": []any{
+				map[string]any{"host": "edc.example.org", "http": map[string]any{"paths": []any{map[string]any{"path": "/api"}}}},
+				map[string]any{"host": "edc.internal"},
+				map[string]any{"host": "*.example.org"},
+			},
+		}),
+		"httproutes": object("gateway.networking.k8s.io/v1", "HTTPRoute", "product-portal", "portal", map[string]any{
+			"hostnames":  []any{"portal.example.org"},
+			"parentRefs": []any{map[string]any{"name": "public", "namespace": "gateways", "sectionName": "https"}},
+		}),
+		"gateways": object("gateway.networking.k8s.io/v1", "Gateway", "gateways", "public", map[string]any{
+			"listeners": []any{
+				map[string]any{"name": "http", "protocol": "HTTP"},
+				map[string]any{"name": "https", "protocol": "HTTPS", "hostname": "*.example.org"},
+			},
+		}),
+		"routes": object("route.openshift.io/v1", "Route", "product-bpdm", "gate", map[string]any{
+			"host": "bpdm.apps.example.org",
+			"path": "/",
+		}),
+	})
+	applications := app.Applications{Items: []app.Item{
+		managing(application("edc", "product-edc"), app.Resource{Group: "networking.k8s.io", Version: "v1", Kind: "Ingress", Namespace: "product-edc", Name: "controlplane"}),
+		managing(application("portal", "product-portal"), app.Resource{Group: "gateway.networking.k8s.io", Kind: "HTTPRoute", Namespace: "product-portal", Name: "portal"}),
+		managing(application("bpdm", "product-bpdm"), app.Resource{Group: "route.openshift.io", Version: "v1", Kind: "Route", Namespace: "product-bpdm", Name: "gate"}),
+	}}
+
+	gateway := &ApplicationGateway{dynamicClient: dynamicClient}
+	gateway.addDiscoveredUrls(context.Background(), applications)
+
+	expected := [][]string{
+		{"https://edc.example.org/api", "http://edc.internal"},
+		{"https://portal.example.org"},
+		{"http://bpdm.apps.example.org"},
+	}
+	for i, urls := range expected {
+		if got := applications.Items[i].Status.Summary.ExternalUrls; !reflect.DeepEqual(got, urls) {
+			t.Errorf("Discovered URLs of %s not as expected! \nexpected: %v \nGot: %v", applications.Items[i].Metadata.Name, urls, got)
+		}
+	}
+}
+
+func TestShouldSkipRoutingObjectsThatCanNotBeRead(t *testing.T) {
+	item := managing(application("edc", "product-edc"), app.Resource{Group: "networking.k8s.io", Kind: "Ingress", Namespace: "product-edc", Name: "missing"})
+	item.Status.Summary.ExternalUrls = []string{"https://edc.example.org"}
+	applications := app.Applications{Items: []app.Item{item}}
+
+	gateway := &ApplicationGateway{dynamicClient: fake.NewSimpleDynamicClient(runtime.NewScheme())}
+	gateway.addDiscoveredUrls(context.Background(), applications)
+
+	if got := applications.Items[0].Status.Summary.ExternalUrls; !reflect.DeepEqual(got, []string{"https://edc.example.org"}) {
+		t.Errorf("URLs reported by Argo CD should be kept! \nexpected: %v \nGot: %v", item.Status.Summary.ExternalUrls, got)
+	}
+}
+
+func TestShouldMergeDiscoveredUrlsIgnoringSchemeAndTrailingSlash(t *testing.T) {
+	reported := []string{"http://edc.example.org/", "https://portal.example.org"}
+	discovered := []string{"https://edc.example.org", "http://portal.example.org", "https://bpdm.example.org"}
+
+	merged := mergeUrls(reported, discovered)
+
+	expected := []string{"https://edc.example.org/", "https://portal.example.org", "https://bpdm.example.org"}
+	if !reflect.DeepEqual(merged, expected) {
+		t.Errorf("Merged URLs not as expected! \nexpected: %v \nGot: %v", expected, merged)
+	}
+	if reported[0] != "http://edc.example.org/" {
+		t.Errorf("The reported URLs should not be modified! \nexpected: %s \nGot: %s", "http://edc.example.org/", reported[0])
+	}
+}
+
+func TestShouldMatchWildcardHostsOfASingleLabel(t *testing.T) {
+	tests := []struct {
+		host     string
+		patterns []string
+		expected bool
+	}{
+		{"edc.example.org", []string{"*.example.org"}, true},
+		{"a.edc.example.org", []string{"*.example.org"}, false},
+		{"example.org", []string{"*.example.org"}, false},
+		{"edc.example.org", []string{"*"}, true},
+		{"edc.example.org", []string{"portal.example.org"}, false},
+	}
+	for _, test := range tests {
+		if got := matchesAnyHost(test.host, test.patterns); got != test.expected {
+			t.Errorf("Matching %s against %v not as expected! \nexpected: %v \nGot: %v", test.host, test.patterns, test.expected, got)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+// Package owner reads who is responsible for an application from the labels and annotations of the Application.
+package owner
+
+import (
+	"context"
+	"strings"
+
+	"dashboard/internal/app"
+)
+
+// DefaultKeys are the labels and annotations read if no keys are configured.
+var DefaultKeys = []string{"team", "owner", "contact", "slack-channel", "docs-url"}
+
+// Enricher sets the owner of the applications from the labels and annotations with the configured keys, in the order
+// of the keys. Labels take precedence over annotations with the same key, unless they are empty.
+type Enricher struct {
+	keys []string
+}
+
+func NewEnricher(keys []string) *Enricher {
+	return &Enricher{keys: keys}
+}
+
+func (enricher *Enricher) Enrich(_ context.Context, applications *app.Applications) error {
+	for i := range applications.Items {
+		applications.Items[i].Owner = enricher.ownerOf(applications.Items[i].Metadata)
+	}
+	return nil
+}
+
+func (enricher *Enricher) ownerOf(metadata app.Metadata) []app.OwnerField {
+	var owner []app.OwnerField
+	for _, key := range enricher.keys {
+		// label values are often restricted, so an empty label doesn't hide the annotation
+		value := strings.TrimSpace(metadata.Labels[key])
+		if value == "" {
+			value = strings.TrimSpace(metadata.Annotations[key])
+		}
+		if value != "" {
+			owner = append(owner, app.OwnerField{Key: key, Value: value})
+		}
+	}
+	return owner
+}
@@ -0,0 +1,67 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package owner
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"dashboard/internal/app"
+)
+
+func TestShouldReadOwnerFromLabelsAndAnnotationsInTheOrderOfTheKeys(t *testing.T) {
+	item := app.Item{Metadata: app.Metadata{
+		Name:        "edc",
+		Labels:      map[string]string{"team": "connector", "app.kubernetes.io/part-of": "edc"},
+		Annotations: map[string]string{"team": "ignored", "docs-url": "https://docs.example.org/edc", "slack-channel": " #edc ", "contact": ""},
+	}}
+	applications := &app.Applications{Items: []app.Item{item, {Metadata: app.Metadata{Name: "portal"}}}}
+
+	if err := NewEnricher([]string{"docs-url", "team", "owner", "contact", "slack-channel"}).Enrich(context.Background(), applications); err != nil {
+		t.Fatalf("Could not enrich applications: %v", err)
+	}
+
+	expected := []app.OwnerField{
+		{Key: "docs-url", Value: "https://docs.example.org/edc"},
+		{Key: "team", Value: "connector"},
+		{Key: "slack-channel", Value: "#edc"},
+	}
+	if !reflect.DeepEqual(applications.Items[0].Owner, expected) {
+		t.Errorf("Owner not read correctly! \nexpected: %v \nGot: %v", expected, applications.Items[0].Owner)
+	}
+	if applications.Items[1].Owner != nil {
+		t.Errorf("Application without labels should have no owner! \nexpected: %v \nGot: %v", nil, applications.Items[1].Owner)
+	}
+}
+
+func TestShouldFallBackToTheAnnotationForEmptyLabels(t *testing.T) {
+	metadata := app.Metadata{
+		Labels:      map[string]string{"team": " ", "owner": ""},
+		Annotations: map[string]string{"team": "connector", "owner": "Jane Doe <jane@example.org>"},
+	}
+
+	owner := NewEnricher([]string{"team", "owner"}).ownerOf(metadata)
+
+	expected := []app.OwnerField{{Key: "team", Value: "connector"}, {Key: "owner", Value: "Jane Doe <jane@example.org>"}}
+	if !reflect.DeepEqual(owner, expected) {
+		t.Errorf("Annotation not used for empty label! \nexpected: %v \nGot: %v", expected, owner)
+	}
+}
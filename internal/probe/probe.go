@@ -0,0 +1,225 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+// Package probe checks in the background whether the external URLs of the applications are reachable and when their
+// TLS certificates expire.
+package probe
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"dashboard/internal/app"
+)
+
+const (
+	// maxConcurrentProbes limits the requests sent at once, environments have some hundred URLs.
+	maxConcurrentProbes = 8
+	// maxFirstProbeWait limits how long a sync waits for new URLs to be probed.
+	maxFirstProbeWait = 5 * time.Second
+)
+
+// Prober periodically requests the external URLs seen by the last enrichment. As an enricher it attaches the
+// latest results to the applications; URLs are probed in the background, a sync only waits up to maxFirstProbeWait
+// for the first results of new URLs, so slow URLs don't delay it.
+type Prober struct {
+	client   *http.Client
+	interval time.Duration
+	mutex    sync.Mutex
+	targets  map[string]bool
+	results  map[string]app.Probe
+	// wake triggers probing new URLs without waiting for the interval
+	wake chan struct{}
+	// running is set while Run probes in the background
+	running bool
+	// probed is closed once the next run has probed the current targets
+	probed chan struct{}
+}
+
+// NewProber creates a prober using the client, which should have a timeout. Redirects are not followed, a
+// redirect already shows that the URL is served.
+func NewProber(client *http.Client, interval time.Duration) *Prober {
+	noRedirects := *client
+	noRedirects.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return &Prober{
+		client:   &noRedirects,
+		interval: interval,
+		targets:  map[string]bool{},
+		results:  map[string]app.Probe{},
+		wake:     make(chan struct{}, 1),
+		probed:   make(chan struct{}),
+	}
+}
+
+// Enrich remembers the external URLs of the applications as the URLs to probe and attaches the latest results. If
+// there are new URLs, it waits briefly for them to be probed.
+func (prober *Prober) Enrich(ctx context.Context, applications *app.Applications) error {
+	prober.mutex.Lock()
+	targets := map[string]bool{}
+	unprobed := false
+	for _, item := range applications.Items {
+		for _, url := range item.Status.Summary.ExternalUrls {
+			targets[url] = true
+			if _, found := prober.results[url]; !found {
+				unprobed = true
+			}
+		}
+	}
+	prober.targets = targets
+	probed, running := prober.probed, prober.running
+	prober.mutex.Unlock()
+
+	if unprobed {
+		select {
+		case prober.wake <- struct{}{}:
+		default:
+		}
+		if running {
+			timer := time.NewTimer(maxFirstProbeWait)
+			select {
+			case <-probed:
+			case <-timer.C:
+			case <-ctx.Done():
+			}
+			timer.Stop()
+		}
+	}
+
+	prober.mutex.Lock()
+	defer prober.mutex.Unlock()
+	for i := range applications.Items {
+		item := &applications.Items[i]
+		for _, url := range item.Status.Summary.ExternalUrls {
+			result, found := prober.results[url]
+			if !found {
+				continue
+			}
+			if item.Probes == nil {
+				item.Probes = map[string]app.Probe{}
+			}
+			item.Probes[url] = result
+		}
+	}
+	return nil
+}
+
+// Run probes the URLs every interval, and as soon as new URLs show up, until the context is done.
+func (prober *Prober) Run(ctx context.Context) {
+	prober.mutex.Lock()
+	prober.running = true
+	prober.mutex.Unlock()
+	defer func() {
+		prober.mutex.Lock()
+		prober.running = false
+		prober.mutex.Unlock()
+	}()
+
+	ticker := time.NewTicker(prober.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-prober.wake:
+		}
+		prober.probeAll(ctx)
+	}
+}
+
+func (prober *Prober) probeAll(ctx context.Context) {
+	prober.mutex.Lock()
+	urls := make([]string, 0, len(prober.targets))
+	for url := range prober.targets {
+		urls = append(urls, url)
+	}
+	// syncs waiting for the current targets are released once this run is done
+	probed := prober.probed
+	prober.probed = make(chan struct{})
+	prober.mutex.Unlock()
+	defer close(probed)
+
+	results := make([]app.Probe, len(urls))
+	semaphore := make(chan struct{}, maxConcurrentProbes)
+	var wait sync.WaitGroup
+	for i, url := range urls {
+		wait.Add(1)
+		semaphore <- struct{}{}
+		go func(i int, url string) {
+			defer wait.Done()
+			defer func() { <-semaphore }()
+			results[i] = prober.probe(ctx, url)
+		}(i, url)
+	}
+	wait.Wait()
+	if ctx.Err() != nil {
+		return
+	}
+
+	prober.mutex.Lock()
+	defer prober.mutex.Unlock()
+	for i, url := range urls {
+		prober.results[url] = results[i]
+	}
+	// forget URLs no longer in use
+	for url := range prober.results {
+		if !prober.targets[url] {
+			delete(prober.results, url)
+		}
+	}
+}
+
+func (prober *Prober) probe(ctx context.Context, url string) app.Probe {
+	result := app.Probe{CheckedAt: time.Now()}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	response, err := prober.client.Do(request)
+	result.Latency = time.Since(result.CheckedAt)
+	if err != nil {
+		result.Error = err.Error()
+		// the handshake fails for expired certificates, record the expiry to point it out
+		var invalid x509.CertificateInvalidError
+		if errors.As(err, &invalid) && invalid.Reason == x509.Expired && invalid.Cert != nil {
+			expiry := invalid.Cert.NotAfter
+			result.CertificateExpiry = &expiry
+		}
+		return result
+	}
+	defer response.Body.Close()
+	// drain a little of the body, so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 4096))
+
+	result.StatusCode = response.StatusCode
+	if response.TLS != nil && len(response.TLS.PeerCertificates) > 0 {
+		expiry := response.TLS.PeerCertificates[0].NotAfter
+		result.CertificateExpiry = &expiry
+	}
+	return result
+}
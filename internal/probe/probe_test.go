@@ -0,0 +1,241 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package probe
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"dashboard/internal/app"
+)
+
+func applicationsWithUrls(urls ...string) *app.Applications {
+	item := app.Item{Metadata: app.Metadata{Name: "portal", Namespace: "argocd"}}
+	item.Status.Summary.ExternalUrls = urls
+	return &app.Applications{Items: []app.Item{item}}
+}
+
+func probed(t *testing.T, prober *Prober, urls ...string) map[string]app.Probe {
+	if err := prober.Enrich(context.Background(), applicationsWithUrls(urls...)); err != nil {
+		t.Fatalf("Could not enrich applications: %v", err)
+	}
+	prober.probeAll(context.Background())
+
+	applications := applicationsWithUrls(urls...)
+	if err := prober.Enrich(context.Background(), applications); err != nil {
+		t.Fatalf("Could not enrich applications: %v", err)
+	}
+	return applications.Items[0].Probes
+}
+
+func TestShouldRecordStatusLatencyAndCertificateExpiry(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	probes := probed(t, NewProber(server.Client(), time.Hour), server.URL)
+
+	result, found := probes[server.URL]
+	if !found {
+		t.Fatalf("URL %s was not probed", server.URL)
+	}
+	if !result.IsReachable() || result.StatusCode != http.StatusOK || result.Latency <= 0 {
+		t.Errorf("URL should be reachable! \nexpected: %d \nGot: %+v", http.StatusOK, result)
+	}
+	expectedExpiry := server.Certificate().NotAfter
+	if result.CertificateExpiry == nil || !result.CertificateExpiry.Equal(expectedExpiry) {
+		t.Errorf("Certificate expiry not recorded! \nexpected: %v \nGot: %v", expectedExpiry, result.CertificateExpiry)
+	}
+}
+
+func TestShouldCountRedirectsAsReachableWithoutFollowingThem(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/" {
+			http.Redirect(w, r, "/login", http.StatusFound)
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	result := probed(t, NewProber(server.Client(), time.Hour), server.URL+"/")[server.URL+"/"]
+
+	if !result.IsReachable() || result.StatusCode != http.StatusFound {
+		t.Errorf("Redirect should be reachable! \nexpected: %d \nGot: %+v", http.StatusFound, result)
+	}
+}
+
+func TestShouldReportServerErrorsAndUnreachableUrls(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer failing.Close()
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	probes := probed(t, NewProber(&http.Client{Timeout: 5 * time.Second}, time.Hour), failing.URL, closed.URL)
+
+	if result := probes[failing.URL]; result.IsReachable() || result.StatusCode != http.StatusBadGateway {
+		t.Errorf("Server error should not be reachable! \nexpected: %d \nGot: %+v", http.StatusBadGateway, result)
+	}
+	if result := probes[closed.URL]; result.IsReachable() || result.Error == "" || result.CertificateExpiry != nil {
+		t.Errorf("Closed server should not be reachable! \nexpected: an error \nGot: %+v", result)
+	}
+}
+
+func TestShouldReportUntrustedCertificates(t *testing.T) {
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+
+	result := probed(t, NewProber(&http.Client{Timeout: 5 * time.Second}, time.Hour), server.URL)[server.URL]
+
+	if result.IsReachable() || result.Error == "" {
+		t.Errorf("Untrusted certificate should not be reachable! \nexpected: an error \nGot: %+v", result)
+	}
+}
+
+func TestShouldRecordTheExpiryOfExpiredCertificates(t *testing.T) {
+	expiry := time.Now().Add(-24 * time.Hour).Truncate(time.Second).UTC()
+	certificate := givenCertificate(t, expiry)
+	server := httptest.NewUnstartedServer(http.NotFoundHandler())
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{certificate}}
+	server.StartTLS()
+	defer server.Close()
+	trusted := x509.NewCertPool()
+	trusted.AddCert(certificate.Leaf)
+	client := &http.Client{Timeout: 5 * time.Second, Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: trusted}}}
+
+	result := probed(t, NewProber(client, time.Hour), server.URL)[server.URL]
+
+	if result.IsReachable() || result.CertificateExpiry == nil || !result.CertificateExpiry.Equal(expiry) {
+		t.Errorf("Expiry of expired certificate not recorded! \nexpected: %v \nGot: %+v", expiry, result)
+	}
+}
+
+// givenCertificate creates a self-signed certificate for 127.0.0.1 expiring at the given time.
+func givenCertificate(t *testing.T, notAfter time.Time) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "127.0.0.1"},
+		NotBefore:             notAfter.Add(-30 * 24 * time.Hour),
+		NotAfter:              notAfter,
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}
+}
+
+func TestShouldForgetUrlsNoLongerInUse(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	prober := NewProber(server.Client(), time.Hour)
+	probed(t, prober, server.URL+"/old")
+
+	probes := probed(t, prober, server.URL+"/new")
+
+	if _, found := prober.results[server.URL+"/old"]; found {
+		t.Errorf("Result of unused URL should be dropped \nexpected: %v \nGot: %v", "no result", prober.results)
+	}
+	if len(probes) != 1 {
+		t.Errorf("Only the URLs of the application should be attached \nexpected: %d \nGot: %d", 1, len(probes))
+	}
+}
+
+func TestShouldProbeNewUrlsWithoutWaitingForTheInterval(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	prober := NewProber(server.Client(), time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go prober.Run(ctx)
+	waitUntilRunning(t, prober)
+
+	applications := applicationsWithUrls(server.URL)
+	if err := prober.Enrich(ctx, applications); err != nil {
+		t.Fatalf("Could not enrich applications: %v", err)
+	}
+
+	if _, found := applications.Items[0].Probes[server.URL]; !found {
+		t.Errorf("New URL was not probed during the sync \nexpected: %s \nGot: %v", server.URL, applications.Items[0].Probes)
+	}
+}
+
+func TestShouldNotWaitLongerThanTheLimitForNewUrls(t *testing.T) {
+	slow := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-slow
+	}))
+	defer server.Close()
+	defer close(slow)
+	prober := NewProber(server.Client(), time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go prober.Run(ctx)
+	waitUntilRunning(t, prober)
+
+	start := time.Now()
+	applications := applicationsWithUrls(server.URL)
+	_ = prober.Enrich(ctx, applications)
+
+	if elapsed := time.Since(start); elapsed > maxFirstProbeWait+time.Second || len(applications.Items[0].Probes) != 0 {
+		t.Errorf("Sync should stop waiting for slow URLs \nexpected: %v \nGot: %v %v", maxFirstProbeWait, elapsed, applications.Items[0].Probes)
+	}
+}
+
+func waitUntilRunning(t *testing.T, prober *Prober) {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		prober.mutex.Lock()
+		running := prober.running
+		prober.mutex.Unlock()
+		if running {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("Prober did not start")
+}
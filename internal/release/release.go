@@ -0,0 +1,275 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+// Package release checks whether the installed applications conform to the product versions of a release manifest.
+package release
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"dashboard/internal/app"
+	"dashboard/internal/semver"
+)
+
+// Manifest lists the product versions a release consists of.
+type Manifest struct {
+	Release  string    `json:"release"`
+	Products []Product `json:"products"`
+}
+
+type Product struct {
+	Name string `json:"name"`
+	// Chart defaults to the product name
+	Chart string `json:"chart,omitempty"`
+	// Version is the expected chart version
+	Version string `json:"version,omitempty"`
+	// Images are the expected images with tags, i.e. tractusx/app-dashboard:1.0.0
+	Images []string `json:"images,omitempty"`
+	// Applications are the names of the Argo CD applications installing the product, by default the applications
+	// deploying the chart or named like the product
+	Applications []string `json:"applications,omitempty"`
+}
+
+type Status string
+
+const (
+	Match    Status = "match"
+	Ahead    Status = "ahead"
+	Behind   Status = "behind"
+	Mismatch Status = "mismatch"
+	Missing  Status = "missing"
+)
+
+// Statuses lists all statuses, ordered from best to worst.
+var Statuses = []Status{Match, Ahead, Behind, Mismatch, Missing}
+
+type Result struct {
+	Product     string
+	Namespace   string
+	Application string
+	Expected    string
+	Deployed    string
+	Status      Status
+	// Details explain deviations, i.e. of single images
+	Details []string
+}
+
+type Report struct {
+	Release string
+	Results []Result
+}
+
+// Load reads a manifest in YAML or JSON.
+func Load(path string) (*Manifest, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read release manifest: %w", err)
+	}
+
+	var manifest Manifest
+	if err := yaml.UnmarshalStrict(raw, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid release manifest %s: %w", path, err)
+	}
+	for i, product := range manifest.Products {
+		if strings.TrimSpace(product.Name) == "" {
+			return nil, fmt.Errorf("invalid release manifest %s: product %d has no name", path, i+1)
+		}
+		if product.Version == "" && len(product.Images) == 0 {
+			return nil, fmt.Errorf("invalid release manifest %s: product %s expects neither a version nor images", path, product.Name)
+		}
+	}
+	return &manifest, nil
+}
+
+// Check compares the installed applications with the products of the manifest. Products without an application
+// are reported as missing, applications not belonging to any product are left out.
+func (manifest *Manifest) Check(items []app.Item) Report {
+	report := Report{Release: manifest.Release}
+	for _, product := range manifest.Products {
+		found := false
+		for _, item := range items {
+			if !product.installedBy(item) {
+				continue
+			}
+			found = true
+			report.Results = append(report.Results, product.check(item))
+		}
+
+		if !found {
+			report.Results = append(report.Results, Result{Product: product.Name, Expected: product.expected(), Status: Missing})
+		}
+	}
+	return report
+}
+
+// ResultOf returns the result for the application or nil, if it belongs to no product of the release.
+func (report Report) ResultOf(item app.Item) *Result {
+	for i, result := range report.Results {
+		if result.Namespace == item.Metadata.Namespace && result.Application == item.Metadata.Name {
+			return &report.Results[i]
+		}
+	}
+	return nil
+}
+
+// Count returns the number of results with the status.
+func (report Report) Count(status Status) int {
+	count := 0
+	for _, result := range report.Results {
+		if result.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+func (product Product) installedBy(item app.Item) bool {
+	if len(product.Applications) > 0 {
+		for _, name := range product.Applications {
+			if name == item.Metadata.Name {
+				return true
+			}
+		}
+		return false
+	}
+	return item.Spec.Source.Chart == product.chart() || item.Metadata.Name == product.Name
+}
+
+func (product Product) chart() string {
+	if product.Chart != "" {
+		return product.Chart
+	}
+	return product.Name
+}
+
+func (product Product) expected() string {
+	return strings.Join(append(nonEmpty(product.Version), product.Images...), ", ")
+}
+
+func (product Product) check(item app.Item) Result {
+	result := Result{
+		Product:     product.Name,
+		Namespace:   item.Metadata.Namespace,
+		Application: item.Metadata.Name,
+		Expected:    product.expected(),
+	}
+
+	var statuses []Status
+	var deployed []string
+	if product.Version != "" {
+		version := item.DeployedVersion()
+		deployed = append(deployed, version)
+		status := compare(version, product.Version)
+		if status != Match {
+			result.Details = append(result.Details, fmt.Sprintf("chart %s is %s %s", version, status, product.Version))
+		}
+		statuses = append(statuses, status)
+	}
+
+	for _, expected := range product.Images {
+		repository, expectedTag := splitImage(expected)
+		image, found := findImage(item.Status.Summary.Images, repository)
+		if !found {
+			result.Details = append(result.Details, "image "+expected+" not deployed")
+			statuses = append(statuses, Mismatch)
+			continue
+		}
+
+		deployed = append(deployed, image)
+		_, tag := splitImage(image)
+		status := compare(tag, expectedTag)
+		if status != Match {
+			result.Details = append(result.Details, fmt.Sprintf("image %s is %s %s", image, status, expectedTag))
+		}
+		statuses = append(statuses, status)
+	}
+
+	result.Deployed = strings.Join(deployed, ", ")
+	result.Status = combine(statuses)
+	return result
+}
+
+// compare orders semantic versions and tells versions, that can't be ordered, only apart by equality.
+func compare(deployed string, expected string) Status {
+	deployedVersion, deployedOk := semver.Parse(deployed)
+	expectedVersion, expectedOk := semver.Parse(expected)
+	if !deployedOk || !expectedOk {
+		if deployed == expected {
+			return Match
+		}
+		return Mismatch
+	}
+
+	switch deployedVersion.Compare(expectedVersion) {
+	case 1:
+		return Ahead
+	case -1:
+		return Behind
+	}
+	return Match
+}
+
+// combine reports the worst status; deviations in both directions are a mismatch.
+func combine(statuses []Status) Status {
+	seen := map[Status]bool{}
+	for _, status := range statuses {
+		seen[status] = true
+	}
+	switch {
+	case seen[Mismatch] || (seen[Ahead] && seen[Behind]):
+		return Mismatch
+	case seen[Behind]:
+		return Behind
+	case seen[Ahead]:
+		return Ahead
+	}
+	return Match
+}
+
+// findImage finds the deployed image of the repository, ignoring the registry if the expected image has none.
+func findImage(images []string, repository string) (string, bool) {
+	for _, image := range images {
+		deployedRepository, _ := splitImage(image)
+		if deployedRepository == repository || strings.HasSuffix(deployedRepository, "/"+repository) {
+			return image, true
+		}
+	}
+	return "", false
+}
+
+// splitImage splits an image into repository and tag, a registry port is no tag.
+func splitImage(image string) (string, string) {
+	image, _, _ = strings.Cut(image, "@")
+	separator := strings.LastIndex(image, ":")
+	if separator < 0 || strings.Contains(image[separator:], "/") {
+		return image, "latest"
+	}
+	return image[:separator], image[separator+1:]
+}
+
+func nonEmpty(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return []string{value}
+}
@@ -0,0 +1,158 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package release
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"dashboard/internal/app"
+)
+
+const manifestYaml = `release: "24.03"
+products:
+  - name: tractusx-connector
+    version: 0.6.0
+    images:
+      - tractusx/edc-controlplane-postgresql-hashicorp-vault:0.6.0
+  - name: portal
+    chart: portal
+    version: 1.8.0
+  - name: bpdm
+    version: 4.0.0
+    applications: [bpdm-pool, bpdm-gate]
+  - name: semantic-hub
+    version: 0.2.0
+`
+
+func givenManifest(t *testing.T) *Manifest {
+	path := filepath.Join(t.TempDir(), "release.yaml")
+	if err := os.WriteFile(path, []byte(manifestYaml), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	manifest, err := Load(path)
+	if err != nil {
+		t.Fatalf("Manifest not loaded! Got: %v", err)
+	}
+	return manifest
+}
+
+func chartApplication(name string, chart string, version string, images ...string) app.Item {
+	source := app.Source{RepoUrl: "https://eclipse-tractusx.github.io/charts/dev", Chart: chart, TargetRevision: version}
+	return app.Item{
+		Metadata: app.Metadata{Name: name, Namespace: "product-" + name},
+		Spec:     app.Spec{Source: source},
+		Status: app.Status{
+			History: []app.History{{Id: 1, Revision: version, Source: source}},
+			Summary: app.Summary{Images: images},
+		},
+	}
+}
+
+func TestShouldReportConformanceOfEveryProduct(t *testing.T) {
+	items := []app.Item{
+		chartApplication("edc", "tractusx-connector", "0.6.0", "docker.io/tractusx/edc-controlplane-postgresql-hashicorp-vault:0.6.0"),
+		chartApplication("portal", "portal", "1.9.0-RC1"),
+		chartApplication("bpdm-pool", "bpdm-pool", "3.1.0"),
+		chartApplication("bpdm-gate", "bpdm-gate", "4.0.0"),
+		chartApplication("irs", "irs-helm", "6.9.1"),
+	}
+
+	report := givenManifest(t).Check(items)
+
+	expected := []Status{Match, Ahead, Behind, Match, Missing}
+	var statuses []Status
+	for _, result := range report.Results {
+		statuses = append(statuses, result.Status)
+	}
+	if report.Release != "24.03" || !reflect.DeepEqual(statuses, expected) {
+		t.Errorf("Conformance not reported correctly! \nexpected: %v \nGot: %v", expected, report.Results)
+	}
+	if report.ResultOf(items[4]) != nil {
+		t.Errorf("Application not in the release should have no result! Got: %v", report.ResultOf(items[4]))
+	}
+	if count := report.Count(Match); count != 2 {
+		t.Errorf("Matches not counted! \nexpected: %d \nGot: %d", 2, count)
+	}
+}
+
+func TestShouldUseLatestDeployedRevisionInsteadOfVersionRange(t *testing.T) {
+	item := chartApplication("portal", "portal", "1.8.0")
+	item.Spec.Source.TargetRevision = "1.*"
+
+	result := givenManifest(t).Check([]app.Item{item}).ResultOf(item)
+
+	if result == nil || result.Status != Match || result.Deployed != "1.8.0" {
+		t.Errorf("Deployed revision not used! \nexpected: %s \nGot: %v", Match, result)
+	}
+}
+
+func TestShouldReportDeviatingAndMissingImages(t *testing.T) {
+	manifest := &Manifest{Products: []Product{{Name: "edc", Images: []string{"tractusx/edc-controlplane:0.6.0", "tractusx/edc-dataplane:0.6.0"}}}}
+	item := chartApplication("edc", "tractusx-connector", "0.6.0", "tractusx/edc-controlplane:0.5.3", "postgres:15")
+
+	result := manifest.Check([]app.Item{item}).ResultOf(item)
+
+	expectedDetails := []string{"image tractusx/edc-controlplane:0.5.3 is behind 0.6.0", "image tractusx/edc-dataplane:0.6.0 not deployed"}
+	if result == nil || result.Status != Mismatch || !reflect.DeepEqual(result.Details, expectedDetails) {
+		t.Errorf("Image deviations not reported! \nexpected: %v \nGot: %v", expectedDetails, result)
+	}
+}
+
+func TestShouldCompareNonSemanticVersionsByEquality(t *testing.T) {
+	tests := []struct {
+		deployed string
+		expected string
+		status   Status
+	}{
+		{"main", "main", Match},
+		{"main", "1.0.0", Mismatch},
+		{"v1.0.0", "1.0.0", Match},
+		{"1.0.0", "1.0.0-rc.1", Ahead},
+	}
+
+	for _, test := range tests {
+		if status := compare(test.deployed, test.expected); status != test.status {
+			t.Errorf("Versions %s and %s not compared correctly! \nexpected: %s \nGot: %s", test.deployed, test.expected, test.status, status)
+		}
+	}
+}
+
+func TestShouldSplitImagesWithRegistryPort(t *testing.T) {
+	repository, tag := splitImage("registry.example.org:5000/tractusx/portal")
+
+	if repository != "registry.example.org:5000/tractusx/portal" || tag != "latest" {
+		t.Errorf("Image not split correctly! \nexpected: %s \nGot: %s, %s", "registry.example.org:5000/tractusx/portal, latest", repository, tag)
+	}
+}
+
+func TestShouldRejectInvalidManifests(t *testing.T) {
+	for _, manifest := range []string{"products:\n  - version: 1.0.0\n", "products:\n  - name: portal\n", "unknown: true\n"} {
+		path := filepath.Join(t.TempDir(), "release.yaml")
+		_ = os.WriteFile(path, []byte(manifest), 0o600)
+
+		if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "invalid release manifest") {
+			t.Errorf("Invalid manifest %q should be rejected! Got: %v", manifest, err)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+// Package semver parses and orders semantic versions as used for Helm chart versions.
+package semver
+
+import (
+	"strconv"
+	"strings"
+)
+
+type Version struct {
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease []string
+}
+
+// Parse reads versions like 1.2.3, v1.2 or 1.2.3-rc.1+build. Build metadata is ignored, missing minor and patch
+// versions default to 0.
+func Parse(raw string) (Version, bool) {
+	raw = strings.TrimPrefix(strings.TrimSpace(raw), "v")
+	raw, _, _ = strings.Cut(raw, "+")
+	core, prerelease, hasPrerelease := strings.Cut(raw, "-")
+
+	parts := strings.Split(core, ".")
+	if len(parts) > 3 {
+		return Version{}, false
+	}
+	numbers := make([]int, 3)
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil || number < 0 || strings.HasPrefix(part, "+") {
+			return Version{}, false
+		}
+		numbers[i] = number
+	}
+
+	version := Version{Major: numbers[0], Minor: numbers[1], Patch: numbers[2]}
+	if hasPrerelease {
+		if prerelease == "" {
+			return Version{}, false
+		}
+		version.Prerelease = strings.Split(prerelease, ".")
+	}
+	return version, true
+}
+
+// IsPrerelease tells whether the version is a release candidate, beta or similar.
+func (version Version) IsPrerelease() bool {
+	return len(version.Prerelease) > 0
+}
+
+// Compare returns -1, 0 or 1 if the version is lower, equal or higher than the other one.
+func (version Version) Compare(other Version) int {
+	for _, diff := range []int{version.Major - other.Major, version.Minor - other.Minor, version.Patch - other.Patch} {
+		if diff != 0 {
+			return sign(diff)
+		}
+	}
+
+	// a release is higher than its prereleases
+	switch {
+	case !version.IsPrerelease() && !other.IsPrerelease():
+		return 0
+	case !version.IsPrerelease():
+		return 1
+	case !other.IsPrerelease():
+		return -1
+	}
+
+	for i := 0; i < len(version.Prerelease) && i < len(other.Prerelease); i++ {
+		if result := compareIdentifier(version.Prerelease[i], other.Prerelease[i]); result != 0 {
+			return result
+		}
+	}
+	return sign(len(version.Prerelease) - len(other.Prerelease))
+}
+
+// compareIdentifier orders numeric identifiers numerically and below alphanumeric ones, which are ordered lexically.
+func compareIdentifier(a string, b string) int {
+	aNumber, aErr := strconv.Atoi(a)
+	bNumber, bErr := strconv.Atoi(b)
+	switch {
+	case aErr == nil && bErr == nil:
+		return sign(aNumber - bNumber)
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func sign(value int) int {
+	switch {
+	case value < 0:
+		return -1
+	case value > 0:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,52 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package semver
+
+import "testing"
+
+func TestShouldOrderVersions(t *testing.T) {
+	ordered := []string{"0.9.0", "1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2",
+		"1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "v1.0.1", "1.2", "1.10.0", "2.0.0+build.1"}
+
+	for i := 0; i+1 < len(ordered); i++ {
+		lower, _ := Parse(ordered[i])
+		higher, _ := Parse(ordered[i+1])
+		if lower.Compare(higher) != -1 || higher.Compare(lower) != 1 {
+			t.Errorf("Versions not ordered correctly! \nexpected: %s < %s \nGot: %d", ordered[i], ordered[i+1], lower.Compare(higher))
+		}
+	}
+}
+
+func TestShouldTreatBuildMetadataAsEqual(t *testing.T) {
+	a, _ := Parse("1.2.3+a")
+	b, _ := Parse("v1.2.3")
+
+	if a.Compare(b) != 0 {
+		t.Errorf("Versions differing in build metadata should be equal! \nexpected: %d \nGot: %d", 0, a.Compare(b))
+	}
+}
+
+func TestShouldRejectInvalidVersions(t *testing.T) {
+	for _, raw := range []string{"", "main", "1.2.3.4", "1.x", "1.2.*", "1.0.0-", "-1.0.0", "3d7377d0af2683eb89f7c572d7f01fa794260e55"} {
+		if _, ok := Parse(raw); ok {
+			t.Errorf("Invalid version %q should not be parsed!", raw)
+		}
+	}
+}
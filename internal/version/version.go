@@ -0,0 +1,53 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+// Package version identifies the build of the dashboard. The release build sets the version, commit and date with
+//
+//	go build -ldflags "-X dashboard/internal/version.version=1.2.0 -X dashboard/internal/version.commit=<sha> -X dashboard/internal/version.date=<RFC 3339>"
+//
+// Without them, the commit and date are taken from the version control information Go embeds in the binary.
+package version
+
+import (
+	"runtime/debug"
+
+	"dashboard/internal/app"
+)
+
+var (
+	version = "dev"
+	commit  = ""
+	date    = ""
+)
+
+// Get returns the build info of the running binary.
+func Get() app.BuildInfo {
+	build := app.BuildInfo{Version: version, Commit: commit, Date: date}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			switch {
+			case setting.Key == "vcs.revision" && build.Commit == "":
+				build.Commit = setting.Value
+			case setting.Key == "vcs.time" && build.Date == "":
+				build.Date = setting.Value
+			}
+		}
+	}
+	return build
+}
@@ -0,0 +1,46 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"dashboard/internal/app"
+)
+
+// about is served under /api/about, it adds the environment and filters to the about section.
+type about struct {
+	Environment string `json:"environment"`
+	Filters     string `json:"filters,omitempty"`
+	app.About
+}
+
+func (web *Webserver) configureAboutEndpoint(mux *http.ServeMux, syncResult *app.ApplicationsSyncResult) {
+	mux.HandleFunc("/api/about", func(w http.ResponseWriter, r *http.Request) {
+		syncResult.RLock()
+		result := about{Environment: syncResult.Environment, Filters: syncResult.Filters, About: syncResult.About}
+		syncResult.RUnlock()
+
+		w.Header().Set("Cache-Control", "no-cache, no-store, no-transform, must-revalidate, private, max-age=0")
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(result)
+	})
+}
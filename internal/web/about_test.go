@@ -0,0 +1,77 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package web
+
+import (
+	"dashboard/internal/app"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func givenSyncResultWithAbout() *app.ApplicationsSyncResult {
+	return &app.ApplicationsSyncResult{
+		Environment: "int",
+		GitVersion:  "v1.28.2",
+		About: app.About{
+			GatewayInfo: app.GatewayInfo{Source: "Kubernetes API", KubernetesVersion: "v1.28.2", Tool: "Argo CD", ToolVersion: "v2.8.4"},
+			Build:       app.BuildInfo{Version: "1.2.0", Commit: "4c3d2e1"},
+			Settings:    []app.Setting{{Name: "filter-config", Value: "<none>"}, {Name: "kubeconfig"}},
+			UpdatedAt:   time.Now(),
+		},
+	}
+}
+
+func TestShouldRenderAboutSection(t *testing.T) {
+	recorder := whenRequestingPage(Config{Branding: DefaultBranding()}, givenSyncResultWithAbout(), "/", t)
+
+	for _, expected := range []string{
+		"<li>Dashboard: 1.2.0, commit 4c3d2e1</li>",
+		"<li>Applications read from: Kubernetes API (refreshed 0s ago)</li>",
+		"<li>GitVersion / K8s cluster: v1.28.2</li>",
+		"<li>Argo CD: v2.8.4</li>",
+		"<li><code>filter-config</code>: &lt;none&gt;</li>",
+		"<li><code>kubeconfig</code>: (empty)</li>",
+	} {
+		if !strings.Contains(recorder.Body.String(), expected) {
+			t.Errorf("Rendered index page does not contain %q!", expected)
+		}
+	}
+}
+
+func TestShouldServeAboutAsJson(t *testing.T) {
+	webserver, err := NewWebserver(Config{Branding: DefaultBranding()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux := http.NewServeMux()
+	webserver.configureAboutEndpoint(mux, givenSyncResultWithAbout())
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/about", nil))
+
+	var result map[string]any
+	if err := json.Unmarshal(recorder.Body.Bytes(), &result); err != nil || result["environment"] != "int" ||
+		result["kubernetesVersion"] != "v1.28.2" || result["toolVersion"] != "v2.8.4" {
+		t.Errorf("About not served as JSON! \nexpected: %s \nGot: %s", "int, v1.28.2, Argo CD v2.8.4", recorder.Body.String())
+	}
+}
@@ -21,7 +21,9 @@ package web
 
 import (
 	"dashboard/internal/app"
+	"dashboard/internal/forge"
 	"fmt"
+	"html"
 	"sort"
 	"strings"
 	"time"
@@ -29,8 +31,10 @@ import (
 
 var currentTime = getCurrentTime
 
-func lastAppSyncToHtmlFunc() func(history []app.History) string {
+func lastAppSyncToHtmlFunc(resolver *forge.Resolver) func(history []app.History) string {
 	return func(history []app.History) string {
+		// sort a copy, since concurrent requests render the same history
+		history = append([]app.History(nil), history...)
 		sort.Slice(history, func(i, j int) bool {
 			return history[i].Id > history[j].Id
 		})
@@ -40,7 +44,7 @@ func lastAppSyncToHtmlFunc() func(history []app.History) string {
 		}
 
 		var result string
-		for _, entry := range history {
+		for i, entry := range history {
 
 			t, _ := time.Parse("2006-01-02T15:04:05Z07:00", entry.DeployedAt)
 			duration := currentTime().Sub(t).Round(time.Minute)
@@ -52,25 +56,106 @@ func lastAppSyncToHtmlFunc() func(history []app.History) string {
 				since = fmt.Sprintf("%v", duration)
 			}
 
-			result += "<li>" + entry.DeployedAt + " (" + since + ")<br/>rev: " + linkToRevision(entry.Source) + "</li>"
+			result += "<li>" + entry.DeployedAt + " (" + since + ")<br/>rev: " + revisionToHtml(entry, resolver)
+			if i+1 < len(history) {
+				result += changesToHtml(history[i+1], entry, resolver)
+			}
+			result += "</li>"
 		}
 
 		return result
 	}
 }
 
-func linkToRevision(source app.Source) string {
+func revisionToHtml(entry app.History, resolver *forge.Resolver) string {
+	if entry.ChartVersion != nil {
+		return chartVersionToHtml(entry.Source.Chart, *entry.ChartVersion)
+	}
+	return linkToRevision(entry.Source, resolver)
+}
+
+// chartVersionToHtml describes a chart version from a Helm repository index, linking to the home or the sources of
+// the chart. The index is maintained by third parties, so everything is escaped.
+func chartVersionToHtml(chart string, version app.ChartVersion) string {
+	result := html.EscapeString(chart + " " + version.Version)
+	for _, link := range append([]string{version.Home}, version.Sources...) {
+		if strings.HasPrefix(link, "https://") || strings.HasPrefix(link, "http://") {
+			result = `<a href="` + html.EscapeString(link) + `">` + result + `</a>`
+			break
+		}
+	}
+
+	var details []string
+	if version.AppVersion != "" {
+		details = append(details, "app "+html.EscapeString(version.AppVersion))
+	}
+	if created, err := time.Parse(time.RFC3339, version.Created); err == nil {
+		details = append(details, "released "+created.Format("2006-01-02"))
+	}
+	if len(details) > 0 {
+		result += " (" + strings.Join(details, ", ") + ")"
+	}
+	return result
+}
+
+func linkToRevision(source app.Source, resolver *forge.Resolver) string {
 	// Ignore deployments of released charts from central repo, since there are no tags present in this repo
 	// Information about the origin of the released chart (product repo) not available in current data structure
 	if strings.Contains(source.RepoUrl, "eclipse-tractusx.github.io/charts") {
 		return source.TargetRevision
 	}
 
-	return `<a href="` + ensureHttpGitHubUrl(source.RepoUrl) + `/tree/` + source.TargetRevision + `">` + source.TargetRevision + `</a>`
+	repository, ok := resolver.Resolve(source.RepoUrl)
+	if !ok {
+		return source.TargetRevision
+	}
+	return `<a href="` + repository.TreeUrl(source.TargetRevision) + `">` + source.TargetRevision + `</a>`
 }
 
-func ensureHttpGitHubUrl(url string) string {
-	return strings.TrimSuffix(strings.ReplaceAll(url, "git@github.com:", "https://github.com/"), ".git")
+// changesToHtml links to the comparison with the previous deployment and lists the commits in between, if known.
+func changesToHtml(previous app.History, current app.History, resolver *forge.Resolver) string {
+	if previous.Source.RepoUrl != current.Source.RepoUrl || previous.Revision == current.Revision ||
+		!forge.IsCommitHash(previous.Revision) || !forge.IsCommitHash(current.Revision) {
+		return ""
+	}
+	repository, ok := resolver.Resolve(current.Source.RepoUrl)
+	if !ok {
+		return ""
+	}
+
+	result := `<br/>changes: <a href="` + repository.CompareUrl(previous.Revision, current.Revision) + `">what changed</a>`
+	if len(current.Commits) == 0 {
+		return result
+	}
+
+	result += `<ul class="commits">`
+	for _, commit := range current.Commits {
+		result += `<li><a href="` + repository.CommitUrl(commit.Sha) + `">` + shortSha(commit.Sha) + `</a> ` +
+			html.EscapeString(commit.Subject)
+		if commit.Author != "" {
+			result += " (" + html.EscapeString(commit.Author) + ")"
+		}
+		result += "</li>"
+	}
+	return result + "</ul>"
+}
+
+func shortSha(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+// treeUrlFunc links to the source of an application, falling back to the repository URL for unknown forges.
+func treeUrlFunc(resolver *forge.Resolver) func(source app.Source) string {
+	return func(source app.Source) string {
+		repository, ok := resolver.Resolve(source.RepoUrl)
+		if !ok {
+			return source.RepoUrl
+		}
+		return repository.TreeUrl(source.TargetRevision)
+	}
 }
 
 func getCurrentTime() time.Time {
@@ -21,6 +21,8 @@ package web
 
 import (
 	"dashboard/internal/app"
+	"dashboard/internal/forge"
+	"strings"
 	"testing"
 	"time"
 )
@@ -28,13 +30,13 @@ import (
 func TestShouldRenderNoneForEmptySyncHistory(t *testing.T) {
 	expectedResult := "none"
 
-	renderedHtml := lastAppSyncToHtmlFunc()(nil)
+	renderedHtml := lastAppSyncToHtmlFunc(forge.NewResolver(nil))(nil)
 
 	if renderedHtml != expectedResult {
 		t.Errorf("Did not render corretly for empty sync history! \nexpected: %s \ngot: %s", expectedResult, renderedHtml)
 	}
 
-	renderedHtml = lastAppSyncToHtmlFunc()([]app.History{})
+	renderedHtml = lastAppSyncToHtmlFunc(forge.NewResolver(nil))([]app.History{})
 
 	if renderedHtml != expectedResult {
 		t.Errorf("Did not render corretly for empty sync history! \nexpected: %s \ngot: %s", expectedResult, renderedHtml)
@@ -64,7 +66,7 @@ func TestShouldRenderSyncHistory(t *testing.T) {
 	}
 	expectedHtml := `<li>` + historyEntry.DeployedAt + ` (34m0s)<br/>rev: <a href="https://github.com/eclipse-tractusx/app-dashboard/tree/3d7377d0af2683eb89f7c572d7f01fa794260e55">3d7377d0af2683eb89f7c572d7f01fa794260e55</a></li>`
 
-	renderedHtml := lastAppSyncToHtmlFunc()(historyEntries)
+	renderedHtml := lastAppSyncToHtmlFunc(forge.NewResolver(nil))(historyEntries)
 
 	if renderedHtml != expectedHtml {
 		t.Errorf("Sync history Entry not rendered correctly! \nexpected: %s \nGot: %s", expectedHtml, renderedHtml)
@@ -116,11 +118,13 @@ func TestShouldOrderBySyncHistoryId(t *testing.T) {
 		firstHistoryEntry,
 	}
 
-	expectedHtml := `<li>` + thirdHistoryEntry.DeployedAt + ` (34m0s)<br/>rev: <a href="https://github.com/eclipse-tractusx/app-dashboard/tree/` + thirdHistoryEntry.Source.TargetRevision + `">` + thirdHistoryEntry.Source.TargetRevision + `</a></li>`
-	expectedHtml += `<li>` + secondHistoryEntry.DeployedAt + ` (34m0s)<br/>rev: <a href="https://github.com/eclipse-tractusx/app-dashboard/tree/` + secondHistoryEntry.Source.TargetRevision + `">` + secondHistoryEntry.Source.TargetRevision + `</a></li>`
+	expectedHtml := `<li>` + thirdHistoryEntry.DeployedAt + ` (34m0s)<br/>rev: <a href="https://github.com/eclipse-tractusx/app-dashboard/tree/` + thirdHistoryEntry.Source.TargetRevision + `">` + thirdHistoryEntry.Source.TargetRevision + `</a>`
+	expectedHtml += `<br/>changes: <a href="https://github.com/eclipse-tractusx/app-dashboard/compare/` + secondHistoryEntry.Revision + `...` + thirdHistoryEntry.Revision + `">what changed</a></li>`
+	expectedHtml += `<li>` + secondHistoryEntry.DeployedAt + ` (34m0s)<br/>rev: <a href="https://github.com/eclipse-tractusx/app-dashboard/tree/` + secondHistoryEntry.Source.TargetRevision + `">` + secondHistoryEntry.Source.TargetRevision + `</a>`
+	expectedHtml += `<br/>changes: <a href="https://github.com/eclipse-tractusx/app-dashboard/compare/` + firstHistoryEntry.Revision + `...` + secondHistoryEntry.Revision + `">what changed</a></li>`
 	expectedHtml += `<li>` + firstHistoryEntry.DeployedAt + ` (34m0s)<br/>rev: <a href="https://github.com/eclipse-tractusx/app-dashboard/tree/` + firstHistoryEntry.Source.TargetRevision + `">` + firstHistoryEntry.Source.TargetRevision + `</a></li>`
 
-	renderedHtml := lastAppSyncToHtmlFunc()(historyEntries)
+	renderedHtml := lastAppSyncToHtmlFunc(forge.NewResolver(nil))(historyEntries)
 
 	if renderedHtml != expectedHtml {
 		t.Errorf("Sync history not sorted! \nexpected: %s \nGot: %s", expectedHtml, renderedHtml)
@@ -149,7 +153,7 @@ func TestShouldCutOffGitExtensionsInRepoUrl(t *testing.T) {
 	}
 	expectedHtml := `<li>` + historyEntry.DeployedAt + ` (34m0s)<br/>rev: <a href="https://github.com/eclipse-tractusx/app-dashboard/tree/3d7377d0af2683eb89f7c572d7f01fa794260e55">3d7377d0af2683eb89f7c572d7f01fa794260e55</a></li>`
 
-	renderedHtml := lastAppSyncToHtmlFunc()(historyEntries)
+	renderedHtml := lastAppSyncToHtmlFunc(forge.NewResolver(nil))(historyEntries)
 
 	if renderedHtml != expectedHtml {
 		t.Errorf("Sync history Entry not rendered correctly! \nexpected: %s \nGot: %s", expectedHtml, renderedHtml)
@@ -178,7 +182,7 @@ func TestShouldRenderHttpUrlInsteadOfSshUrl(t *testing.T) {
 	}
 	expectedHtml := `<li>` + historyEntry.DeployedAt + ` (34m0s)<br/>rev: <a href="https://github.com/eclipse-tractusx/app-dashboard/tree/3d7377d0af2683eb89f7c572d7f01fa794260e55">3d7377d0af2683eb89f7c572d7f01fa794260e55</a></li>`
 
-	renderedHtml := lastAppSyncToHtmlFunc()(historyEntries)
+	renderedHtml := lastAppSyncToHtmlFunc(forge.NewResolver(nil))(historyEntries)
 
 	if renderedHtml != expectedHtml {
 		t.Errorf("Sync history Entry not rendered correctly! \nexpected: %s \nGot: %s", expectedHtml, renderedHtml)
@@ -207,9 +211,96 @@ func TestShouldNotRenderLinksForSyncsToCentralChartRepo(t *testing.T) {
 	}
 	expectedHtml := `<li>` + historyEntry.DeployedAt + ` (34m0s)<br/>rev: 3.0.5</li>`
 
-	renderedHtml := lastAppSyncToHtmlFunc()(historyEntries)
+	renderedHtml := lastAppSyncToHtmlFunc(forge.NewResolver(nil))(historyEntries)
 
 	if renderedHtml != expectedHtml {
 		t.Errorf("Sync history Entry not rendered correctly! \nexpected: %s \nGot: %s", expectedHtml, renderedHtml)
 	}
 }
+
+func TestShouldLinkRevisionOnGitLab(t *testing.T) {
+	// overwrite currentTime to make rendered HTML results assertable
+	currentTime = func() time.Time {
+		t, _ := time.Parse(time.RFC3339, "2022-09-18T08:00:00.20Z")
+		return t
+	}
+	historyEntry := app.History{
+		DeployStartedAt: "2022-09-18T07:25:40.20Z",
+		DeployedAt:      "2022-09-18T07:26:00.20Z",
+		Id:              1,
+		Revision:        "b8d56b2d875b183f3109f645443373e18f56783b",
+		Source: app.Source{
+			RepoUrl:        "git@git.example.org:group/app-dashboard.git",
+			TargetRevision: "main",
+		},
+	}
+
+	historyEntries := []app.History{
+		historyEntry,
+	}
+	expectedHtml := `<li>` + historyEntry.DeployedAt + ` (34m0s)<br/>rev: <a href="https://git.example.org/group/app-dashboard/-/tree/main">main</a></li>`
+
+	renderedHtml := lastAppSyncToHtmlFunc(forge.NewResolver(map[string]forge.Kind{"git.example.org": forge.GitLab}))(historyEntries)
+
+	if renderedHtml != expectedHtml {
+		t.Errorf("Sync history Entry not rendered correctly! \nexpected: %s \nGot: %s", expectedHtml, renderedHtml)
+	}
+}
+
+func TestShouldRenderCommitsBetweenDeployments(t *testing.T) {
+	// overwrite currentTime to make rendered HTML results assertable
+	currentTime = func() time.Time {
+		t, _ := time.Parse(time.RFC3339, "2022-09-18T08:00:00.20Z")
+		return t
+	}
+	previousEntry := app.History{
+		DeployedAt: "2022-09-17T07:26:00.20Z",
+		Id:         1,
+		Revision:   "c4232944d8e75ab1e23067f1cf4c88f51f82317e",
+		Source:     app.Source{RepoUrl: "https://gitlab.com/group/app-dashboard.git", TargetRevision: "main"},
+	}
+	currentEntry := app.History{
+		DeployedAt: "2022-09-18T07:26:00.20Z",
+		Id:         2,
+		Revision:   "b8d56b2d875b183f3109f645443373e18f56783b",
+		Source:     app.Source{RepoUrl: "https://gitlab.com/group/app-dashboard.git", TargetRevision: "main"},
+		Commits: []app.Commit{
+			{Sha: "b8d56b2d875b183f3109f645443373e18f56783b", Subject: "Fix <script> rendering", Author: "Jane Doe"},
+			{Sha: "a1d56b2d875b183f3109f645443373e18f56783b", Subject: "Add badges"},
+		},
+	}
+
+	renderedHtml := lastAppSyncToHtmlFunc(forge.NewResolver(nil))([]app.History{previousEntry, currentEntry})
+
+	expectedChanges := `<br/>changes: <a href="https://gitlab.com/group/app-dashboard/-/compare/` + previousEntry.Revision + `...` + currentEntry.Revision + `">what changed</a>` +
+		`<ul class="commits">` +
+		`<li><a href="https://gitlab.com/group/app-dashboard/-/commit/b8d56b2d875b183f3109f645443373e18f56783b">b8d56b2</a> Fix &lt;script&gt; rendering (Jane Doe)</li>` +
+		`<li><a href="https://gitlab.com/group/app-dashboard/-/commit/a1d56b2d875b183f3109f645443373e18f56783b">a1d56b2</a> Add badges</li>` +
+		`</ul></li>`
+	if !strings.Contains(renderedHtml, expectedChanges) {
+		t.Errorf("Commits between deployments not rendered correctly! \nexpected: %s \nGot: %s", expectedChanges, renderedHtml)
+	}
+}
+
+func TestShouldRenderChartVersionsFromRepositoryIndex(t *testing.T) {
+	entry := app.History{
+		DeployedAt: "2022-09-18T07:26:00.20Z",
+		Id:         1,
+		Revision:   "0.6.0",
+		Source:     app.Source{RepoUrl: "https://eclipse-tractusx.github.io/charts/dev", Chart: "tractusx-connector", TargetRevision: "0.6.0"},
+		ChartVersion: &app.ChartVersion{
+			Version:    "0.6.0",
+			AppVersion: "0.6.0<script>",
+			Home:       "javascript:alert(1)",
+			Sources:    []string{"https://github.com/eclipse-tractusx/tractusx-edc"},
+			Created:    "2023-10-05T10:52:35.123456789Z",
+		},
+	}
+
+	renderedHtml := lastAppSyncToHtmlFunc(forge.NewResolver(nil))([]app.History{entry})
+
+	expected := `rev: <a href="https://github.com/eclipse-tractusx/tractusx-edc">tractusx-connector 0.6.0</a> (app 0.6.0&lt;script&gt;, released 2023-10-05)</li>`
+	if !strings.Contains(renderedHtml, expected) {
+		t.Errorf("Chart version not rendered correctly! \nexpected: %s \nGot: %s", expected, renderedHtml)
+	}
+}
@@ -0,0 +1,186 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"dashboard/internal/app"
+)
+
+// applicationPage is the data the application template is rendered with.
+type applicationPage struct {
+	page
+	Item app.Item
+}
+
+type resourceEntry struct {
+	app.Resource
+	NeedsAttention bool `json:"needsAttention"`
+}
+
+type resourceList struct {
+	// Counts are the number of resources by kind
+	Counts    map[string]int  `json:"counts"`
+	Resources []resourceEntry `json:"resources"`
+}
+
+// configureApplicationEndpoints serves the detail page /applications/<namespace>/<name> and the managed resources as
+// JSON under /api/applications/<namespace>/<name>/resources. The query parameter attention=true limits the resources
+// to the ones that are out of sync, degraded or missing. The pods and events, if read, are served under .../pods and
+// .../events.
+func (web *Webserver) configureApplicationEndpoints(mux *http.ServeMux, syncResult *app.ApplicationsSyncResult) {
+	mux.HandleFunc("/applications/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/applications/"), "/")
+
+		var rendered bytes.Buffer
+		var err error
+		syncResult.RLock()
+		item, found := lookupApplication(parts, 2, syncResult)
+		if found {
+			item.Status.Resources = attentionFirst(item.Status.Resources)
+			err = web.template.ExecuteTemplate(&rendered, "application.html", applicationPage{page: web.page(syncResult), Item: item})
+		}
+		syncResult.RUnlock()
+
+		if !found {
+			w.Header().Set("Content-Type", "text/html")
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write(web.errorPage)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Cache-Control", "no-cache, no-store, no-transform, must-revalidate, private, max-age=0")
+		w.WriteHeader(http.StatusOK)
+		_, _ = rendered.WriteTo(w)
+	})
+
+	mux.HandleFunc("/api/applications/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/applications/"), "/")
+
+		syncResult.RLock()
+		item, found := lookupApplication(parts, 3, syncResult)
+		syncResult.RUnlock()
+		if !found {
+			http.NotFound(w, r)
+			return
+		}
+
+		var result any
+		switch parts[2] {
+		case "resources":
+			result = resourcesOf(item, r.URL.Query().Get("attention") == "true")
+		case "pods":
+			if item.Pods == nil {
+				http.NotFound(w, r)
+				return
+			}
+			result = item.Pods
+		case "events":
+			result = append([]app.Event{}, item.Events...)
+		case "probes":
+			result = item.Probes
+			if item.Probes == nil {
+				result = map[string]app.Probe{}
+			}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-cache, max-age=0")
+		_ = json.NewEncoder(w).Encode(result)
+	})
+}
+
+// resourcesOf lists the managed resources of the application, optionally only the ones needing attention.
+func resourcesOf(item app.Item, onlyAttention bool) resourceList {
+	list := resourceList{Counts: map[string]int{}, Resources: []resourceEntry{}}
+	for _, resource := range item.Status.Resources {
+		if onlyAttention && !resource.NeedsAttention() {
+			continue
+		}
+		list.Counts[resource.Kind]++
+		list.Resources = append(list.Resources, resourceEntry{Resource: resource, NeedsAttention: resource.NeedsAttention()})
+	}
+	return list
+}
+
+func lookupApplication(parts []string, expectedParts int, syncResult *app.ApplicationsSyncResult) (app.Item, bool) {
+	if len(parts) != expectedParts {
+		return app.Item{}, false
+	}
+	return findApplication(syncResult.Res, parts[0], parts[1])
+}
+
+// attentionFirst sorts a copy of the resources, so the ones needing attention are on top.
+func attentionFirst(resources []app.Resource) []app.Resource {
+	resources = append([]app.Resource(nil), resources...)
+	sort.SliceStable(resources, func(i, j int) bool {
+		return resources[i].NeedsAttention() && !resources[j].NeedsAttention()
+	})
+	return resources
+}
+
+func applicationUrl(item app.Item) string {
+	return "/applications/" + url.PathEscape(item.Metadata.Namespace) + "/" + url.PathEscape(item.Metadata.Name)
+}
+
+// resourceCountsToHtml counts the resources by kind, i.e. "Deployment (2), Service (3)". Kinds with resources
+// needing attention are highlighted.
+func resourceCountsToHtml(resources []app.Resource) string {
+	counts := map[string]int{}
+	attention := map[string]bool{}
+	for _, resource := range resources {
+		counts[resource.Kind]++
+		attention[resource.Kind] = attention[resource.Kind] || resource.NeedsAttention()
+	}
+	if len(counts) == 0 {
+		return "none"
+	}
+
+	kinds := make([]string, 0, len(counts))
+	for kind := range counts {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	var result []string
+	for _, kind := range kinds {
+		count := fmt.Sprintf("%s (%d)", html.EscapeString(kind), counts[kind])
+		if attention[kind] {
+			count = `<span class="attention">` + count + `</span>`
+		}
+		result = append(result, count)
+	}
+	return strings.Join(result, ", ")
+}
@@ -0,0 +1,182 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package web
+
+import (
+	"dashboard/internal/app"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func givenApplicationWithResources() *app.ApplicationsSyncResult {
+	item := app.Item{}
+	item.Metadata = app.Metadata{Name: "edc", Namespace: "argocd"}
+	item.Spec.Destination.Namespace = "product-edc"
+	item.Status.Resources = []app.Resource{
+		{Kind: "Service", Namespace: "product-edc", Name: "controlplane", Status: "Synced", Health: &app.Health{Status: "Healthy"}},
+		{Kind: "ConfigMap", Namespace: "product-edc", Name: "config", Status: "OutOfSync"},
+		{Kind: "Deployment", Namespace: "product-edc", Name: "controlplane", Status: "Synced", Health: &app.Health{Status: "Healthy"}},
+		{Group: "apps", Kind: "Deployment", Namespace: "product-edc", Name: "dataplane", Status: "Synced",
+			Health: &app.Health{Status: "Degraded", Message: "Deployment <dataplane> exceeded its progress deadline"}},
+	}
+	return &app.ApplicationsSyncResult{Res: app.Applications{Items: []app.Item{item}}, Environment: "int"}
+}
+
+func whenRequestingApplication(syncResult *app.ApplicationsSyncResult, path string, t *testing.T) *httptest.ResponseRecorder {
+	webserver, err := NewWebserver(Config{Branding: DefaultBranding()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux := http.NewServeMux()
+	webserver.configureApplicationEndpoints(mux, syncResult)
+
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+	return recorder
+}
+
+func TestShouldRenderApplicationDetailsWithResourcesNeedingAttentionFirst(t *testing.T) {
+	recorder := whenRequestingApplication(givenApplicationWithResources(), "/applications/argocd/edc", t)
+
+	body := recorder.Body.String()
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Application details not rendered! Got: %d %s", recorder.Code, body)
+	}
+	for _, expected := range []string{
+		"<title>edc - Version Dashboard</title>",
+		`Managed resources: <span class="attention">ConfigMap (1)</span>, <span class="attention">Deployment (2)</span>, Service (1)`,
+		"Deployment &lt;dataplane&gt; exceeded its progress deadline",
+		`href="/api/applications/argocd/edc/resources"`,
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("Rendered application details do not contain %q!", expected)
+		}
+	}
+
+	configMap := strings.Index(body, ">config<")
+	dataplane := strings.Index(body, ">dataplane<")
+	service := strings.Index(body, ">controlplane<")
+	if configMap < 0 || dataplane < 0 || service < configMap || service < dataplane {
+		t.Errorf("Resources needing attention should be listed first! Got positions: %d, %d, %d", configMap, dataplane, service)
+	}
+}
+
+func TestShouldRenderErrorPageForUnknownApplication(t *testing.T) {
+	for _, path := range []string{"/applications/argocd/unknown", "/applications/argocd", "/applications/argocd/edc/more"} {
+		recorder := whenRequestingApplication(givenApplicationWithResources(), path, t)
+
+		if recorder.Code != http.StatusNotFound {
+			t.Errorf("Unknown application %s should not be found! \nexpected: %d \nGot: %d", path, http.StatusNotFound, recorder.Code)
+		}
+	}
+}
+
+func TestShouldListManagedResourcesAsJson(t *testing.T) {
+	recorder := whenRequestingApplication(givenApplicationWithResources(), "/api/applications/argocd/edc/resources", t)
+
+	var list resourceList
+	if err := json.Unmarshal(recorder.Body.Bytes(), &list); err != nil {
+		t.Fatalf("Resources not listed as JSON! Got: %v %s", err, recorder.Body.String())
+	}
+	if len(list.Resources) != 4 || list.Counts["Deployment"] != 2 || !list.Resources[1].NeedsAttention || list.Resources[0].NeedsAttention {
+		t.Errorf("Resources not listed correctly! \nexpected: %s \nGot: %v", "4 resources, 2 deployments, the config map needing attention", list)
+	}
+}
+
+func TestShouldOnlyListResourcesNeedingAttention(t *testing.T) {
+	recorder := whenRequestingApplication(givenApplicationWithResources(), "/api/applications/argocd/edc/resources?attention=true", t)
+
+	var list resourceList
+	_ = json.Unmarshal(recorder.Body.Bytes(), &list)
+	if len(list.Resources) != 2 || list.Resources[0].Name != "config" || list.Resources[1].Name != "dataplane" {
+		t.Errorf("Only resources needing attention expected! \nexpected: %s \nGot: %v", "config, dataplane", list.Resources)
+	}
+}
+
+func TestShouldRenderAndListPodsOfApplication(t *testing.T) {
+	syncResult := givenApplicationWithResources()
+	syncResult.Res.Items[0].Pods = &app.PodSummary{Total: 1, Containers: []app.Container{{
+		Pod: "dataplane-1", Name: "dataplane", Image: "tractusx/edc-dataplane:0.6.1", ImageId: "tractusx/edc-dataplane@sha256:4c3d",
+		State: "Waiting", Reason: "ImagePullBackOff",
+	}}}
+
+	page := whenRequestingApplication(syncResult, "/applications/argocd/edc", t).Body.String()
+	for _, expected := range []string{"<td class=\"main\">sha256:4c3d</td>", "<td class=\"main\">Waiting: ImagePullBackOff</td>", "(unlisted)"} {
+		if !strings.Contains(page, expected) {
+			t.Errorf("Rendered application details do not contain %q!", expected)
+		}
+	}
+
+	var pods app.PodSummary
+	recorder := whenRequestingApplication(syncResult, "/api/applications/argocd/edc/pods", t)
+	if err := json.Unmarshal(recorder.Body.Bytes(), &pods); err != nil || len(pods.Containers) != 1 || pods.Containers[0].Reason != "ImagePullBackOff" {
+		t.Errorf("Pods not listed as JSON! \nexpected: %s \nGot: %s", "dataplane-1 ImagePullBackOff", recorder.Body.String())
+	}
+}
+
+func TestShouldRenderAndListEventsOfApplication(t *testing.T) {
+	syncResult := givenApplicationWithResources()
+	syncResult.Res.Items[0].Events = []app.Event{{
+		Type: "Warning", Reason: "BackOff", Message: "Back-off restarting failed container <dataplane>", Object: "Pod/dataplane-1",
+		Count: 12, LastSeen: time.Date(2023, 10, 20, 8, 0, 0, 0, time.UTC),
+	}}
+
+	page := whenRequestingApplication(syncResult, "/applications/argocd/edc", t).Body.String()
+	for _, expected := range []string{
+		`<h3 id="events">Recent Warning events (1)</h3>`,
+		"<td class=\"main\">2023-10-20 08:00:00</td>",
+		"Back-off restarting failed container &lt;dataplane&gt;",
+	} {
+		if !strings.Contains(page, expected) {
+			t.Errorf("Rendered application details do not contain %q!", expected)
+		}
+	}
+
+	var events []app.Event
+	recorder := whenRequestingApplication(syncResult, "/api/applications/argocd/edc/events", t)
+	if err := json.Unmarshal(recorder.Body.Bytes(), &events); err != nil || len(events) != 1 || events[0].Count != 12 {
+		t.Errorf("Events not listed as JSON! \nexpected: %s \nGot: %s", "1 BackOff event", recorder.Body.String())
+	}
+}
+
+func TestShouldListProbesOfApplication(t *testing.T) {
+	syncResult := givenApplicationWithResources()
+	syncResult.Res.Items[0].Probes = map[string]app.Probe{"https://edc.example.org": {StatusCode: 200, Latency: 80 * time.Millisecond}}
+
+	var probes map[string]app.Probe
+	recorder := whenRequestingApplication(syncResult, "/api/applications/argocd/edc/probes", t)
+	if err := json.Unmarshal(recorder.Body.Bytes(), &probes); err != nil || probes["https://edc.example.org"].StatusCode != 200 {
+		t.Errorf("Probes not listed as JSON! \nexpected: %s \nGot: %s", "https://edc.example.org answering 200", recorder.Body.String())
+	}
+}
+
+func TestShouldAnswerNotFoundForUnknownResourceLists(t *testing.T) {
+	for _, path := range []string{"/api/applications/argocd/unknown/resources", "/api/applications/argocd/edc", "/api/applications/argocd/edc/unknown", "/api/applications/argocd/edc/pods"} {
+		recorder := whenRequestingApplication(givenApplicationWithResources(), path, t)
+
+		if recorder.Code != http.StatusNotFound {
+			t.Errorf("Unknown resource list %s should not be found! \nexpected: %d \nGot: %d", path, http.StatusNotFound, recorder.Code)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package web
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"dashboard/internal/app"
+)
+
+// argoProblemsToHtml renders a warning icon for applications with error conditions or a failed last operation; the
+// tooltip summarizes the problems.
+func argoProblemsToHtml(item app.Item) string {
+	if !item.HasProblems() {
+		return ""
+	}
+
+	var problems []string
+	for _, condition := range item.Status.Conditions {
+		if condition.IsError() {
+			problems = append(problems, condition.Type+": "+condition.Message)
+		}
+	}
+	if state := item.Status.OperationState; state != nil && state.IsFailed() {
+		problems = append(problems, "Last operation "+state.Phase+": "+state.Message)
+	}
+	return `<i class="fa fa-triangle-exclamation" title="` + html.EscapeString(strings.Join(problems, "\n")) +
+		`" style="color: ` + colorRed + `;"></i>`
+}
+
+// argoConditionsToHtml lists the conditions, the last operation and its failed resources, so it is visible why an
+// application is degraded.
+func argoConditionsToHtml(status app.Status) string {
+	state := status.OperationState
+	if len(status.Conditions) == 0 && state == nil && status.Health.Message == "" {
+		return ""
+	}
+
+	var summary []string
+	if len(status.Conditions) > 0 {
+		summary = append(summary, fmt.Sprintf("Conditions (%d)", len(status.Conditions)))
+	}
+	if state != nil {
+		summary = append(summary, "Last operation: "+html.EscapeString(state.Phase))
+	}
+	if len(summary) == 0 {
+		summary = append(summary, "Health")
+	}
+
+	result := `<details class="conditions"><summary>` + strings.Join(summary, " / ") + `</summary><ul>`
+	if status.Health.Message != "" {
+		result += "<li>Health: " + html.EscapeString(status.Health.Message) + "</li>"
+	}
+	for _, condition := range status.Conditions {
+		class := "warning"
+		if condition.IsError() {
+			class = "error"
+		}
+		result += `<li class="` + class + `">` + html.EscapeString(condition.Type) + ": " + html.EscapeString(condition.Message)
+		if condition.LastTransitionTime != "" {
+			result += " (" + html.EscapeString(condition.LastTransitionTime) + ")"
+		}
+		result += "</li>"
+	}
+
+	if state != nil {
+		class := "operation"
+		if state.IsFailed() {
+			class = "error"
+		}
+		result += `<li class="` + class + `">Operation ` + html.EscapeString(state.Phase)
+		if state.Message != "" {
+			result += ": " + html.EscapeString(state.Message)
+		}
+		result += "<br/>started: " + valueOrDash(state.StartedAt) + ", finished: " + valueOrDash(state.FinishedAt)
+		if state.SyncResult != nil {
+			if state.SyncResult.Revision != "" {
+				result += "<br/>revision: " + html.EscapeString(state.SyncResult.Revision)
+			}
+			result += failedResourcesToHtml(state.SyncResult.Resources)
+		}
+		result += "</li>"
+	}
+	return result + "</ul></details>"
+}
+
+func failedResourcesToHtml(resources []app.ResourceResult) string {
+	var result string
+	for _, resource := range resources {
+		if !resource.IsFailed() {
+			continue
+		}
+		name := resource.Kind + " " + resource.Name
+		if resource.Namespace != "" {
+			name = resource.Kind + " " + resource.Namespace + "/" + resource.Name
+		}
+		result += "<li>" + html.EscapeString(name) + ": " + html.EscapeString(resource.Message) + "</li>"
+	}
+	if result == "" {
+		return ""
+	}
+	return `<ul class="failed-resources">` + result + "</ul>"
+}
+
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return html.EscapeString(value)
+}
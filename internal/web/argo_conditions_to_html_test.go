@@ -0,0 +1,97 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package web
+
+import (
+	"dashboard/internal/app"
+	"strings"
+	"testing"
+)
+
+func TestShouldRenderNoWarningForApplicationsWithoutProblems(t *testing.T) {
+	item := app.Item{}
+	item.Status.Conditions = []app.Condition{{Type: "OrphanedResourceWarning", Message: "1 orphaned resource"}}
+	item.Status.OperationState = &app.OperationState{Phase: "Succeeded"}
+
+	if renderedHtml := argoProblemsToHtml(item); renderedHtml != "" {
+		t.Errorf("Warnings and succeeded operations are no problems! \nexpected: %s \nGot: %s", "", renderedHtml)
+	}
+}
+
+func TestShouldRenderWarningForErrorConditionsAndFailedOperations(t *testing.T) {
+	item := app.Item{}
+	item.Status.Conditions = []app.Condition{{Type: "ComparisonError", Message: "rpc error: <unavailable>"}}
+	item.Status.OperationState = &app.OperationState{Phase: "Failed", Message: "hook failed"}
+
+	renderedHtml := argoProblemsToHtml(item)
+
+	expected := `<i class="fa fa-triangle-exclamation" title="ComparisonError: rpc error: &lt;unavailable&gt;` + "\n" +
+		`Last operation Failed: hook failed" style="color: ` + colorRed + `;"></i>`
+	if renderedHtml != expected {
+		t.Errorf("Problems not rendered correctly! \nexpected: %s \nGot: %s", expected, renderedHtml)
+	}
+}
+
+func TestShouldListConditionsAndLastOperation(t *testing.T) {
+	status := app.Status{
+		Health: app.Health{Status: "Degraded", Message: "Deployment exceeded its progress deadline"},
+		Conditions: []app.Condition{
+			{Type: "SyncError", Message: "Failed sync attempt", LastTransitionTime: "2023-10-20T08:00:00Z"},
+			{Type: "SharedResourceWarning", Message: "Secret/db is part of other applications"},
+		},
+		OperationState: &app.OperationState{
+			Phase:      "Failed",
+			Message:    "one or more objects failed to apply",
+			StartedAt:  "2023-10-20T07:59:00Z",
+			FinishedAt: "2023-10-20T08:00:00Z",
+			SyncResult: &app.SyncResult{
+				Revision: "0.6.0",
+				Resources: []app.ResourceResult{
+					{Kind: "Job", Namespace: "product-edc", Name: "migration", HookPhase: "Failed", Message: "Job has reached the specified backoff limit"},
+					{Kind: "Service", Namespace: "product-edc", Name: "edc", Status: "Synced"},
+				},
+			},
+		},
+	}
+
+	renderedHtml := argoConditionsToHtml(status)
+
+	for _, expected := range []string{
+		`<summary>Conditions (2) / Last operation: Failed</summary>`,
+		`<li>Health: Deployment exceeded its progress deadline</li>`,
+		`<li class="error">SyncError: Failed sync attempt (2023-10-20T08:00:00Z)</li>`,
+		`<li class="warning">SharedResourceWarning: Secret/db is part of other applications</li>`,
+		`<li class="error">Operation Failed: one or more objects failed to apply<br/>started: 2023-10-20T07:59:00Z, finished: 2023-10-20T08:00:00Z<br/>revision: 0.6.0`,
+		`<ul class="failed-resources"><li>Job product-edc/migration: Job has reached the specified backoff limit</li></ul>`,
+	} {
+		if !strings.Contains(renderedHtml, expected) {
+			t.Errorf("Conditions not rendered correctly! \nexpected: %s \nGot: %s", expected, renderedHtml)
+		}
+	}
+	if strings.Contains(renderedHtml, "Service") {
+		t.Errorf("Synced resources should not be listed! Got: %s", renderedHtml)
+	}
+}
+
+func TestShouldRenderNothingWithoutConditions(t *testing.T) {
+	if renderedHtml := argoConditionsToHtml(app.Status{Health: app.Health{Status: "Healthy"}}); renderedHtml != "" {
+		t.Errorf("Nothing should be rendered without conditions! \nexpected: %s \nGot: %s", "", renderedHtml)
+	}
+}
@@ -19,23 +19,46 @@
 
 package web
 
+// Colours of the Argo CD UI, shared by the HTML icons and the badges
 const (
-	defaultArgoHealthTemplate     = `<i title="Error" class="fa fa-question-circle" style="color: rgb(233, 109, 118);"></i>`
-	defaultArgoSyncStatusTemplate = `<i title="Error" class="fa fa-question-circle" style="color: rgb(233, 109, 118);"></i>`
+	colorGreen  = "rgb(24, 190, 148)"
+	colorBlue   = "rgb(13, 173, 234)"
+	colorRed    = "rgb(233, 109, 118)"
+	colorPurple = "rgb(118, 111, 148)"
+	colorYellow = "rgb(244, 192, 48)"
+	colorGrey   = "rgb(204, 214, 221)"
+)
+
+const (
+	defaultArgoHealthTemplate     = `<i title="Error" class="fa fa-question-circle" style="color: ` + colorRed + `;"></i>`
+	defaultArgoSyncStatusTemplate = `<i title="Error" class="fa fa-question-circle" style="color: ` + colorRed + `;"></i>`
+	defaultArgoStatusColor        = colorRed
 )
 
 var (
+	argoHealthColors = map[string]string{
+		"Healthy":     colorGreen,
+		"Progressing": colorBlue,
+		"Degraded":    colorRed,
+		"Suspended":   colorPurple,
+		"Missing":     colorYellow,
+		"Unknown":     colorGrey,
+	}
+	argoSyncStatusColors = map[string]string{
+		"Synced":    colorGreen,
+		"OutOfSync": colorYellow,
+	}
 	argoHealthToHtmlTemplate = map[string]string{
-		"Healthy":     `<i title="Healthy" class="fa-solid fa-heart" style="color: rgb(24, 190, 148);"></i>`,
-		"Progressing": `<i title="Progressing" class="fa fa fa-circle-notch" style="color: rgb(13, 173, 234);"></i>`,
-		"Degraded":    `<i title="Degraded" class="fa fa-heart-broken" style="color: rgb(233, 109, 118);"></i>`,
-		"Suspended":   `<i title="Suspended" class="fa fa-pause-circle" style="color: rgb(118, 111, 148);"></i>`,
-		"Missing":     `<i title="Missing" class="fa fa-ghost" style="color: rgb(244, 192, 48);"></i>`,
-		"Unknown":     `<i title="Unknown" class="fa fa-question-circle" style="color: rgb(204, 214, 221);"></i>`,
+		"Healthy":     `<i title="Healthy" class="fa-solid fa-heart" style="color: ` + colorGreen + `;"></i>`,
+		"Progressing": `<i title="Progressing" class="fa fa fa-circle-notch" style="color: ` + colorBlue + `;"></i>`,
+		"Degraded":    `<i title="Degraded" class="fa fa-heart-broken" style="color: ` + colorRed + `;"></i>`,
+		"Suspended":   `<i title="Suspended" class="fa fa-pause-circle" style="color: ` + colorPurple + `;"></i>`,
+		"Missing":     `<i title="Missing" class="fa fa-ghost" style="color: ` + colorYellow + `;"></i>`,
+		"Unknown":     `<i title="Unknown" class="fa fa-question-circle" style="color: ` + colorGrey + `;"></i>`,
 	}
 	argoSyncStatusToHtmlTemplate = map[string]string{
-		"Synced":    `<i title="Synced" class="fa fa-check-circle" style="color: rgb(24, 190, 148);"></i>`,
-		"OutOfSync": `<i title="OutOfSync" class="fa fa-arrow-alt-circle-up" style="color: rgb(244, 192, 48);"></i>`,
+		"Synced":    `<i title="Synced" class="fa fa-check-circle" style="color: ` + colorGreen + `;"></i>`,
+		"OutOfSync": `<i title="OutOfSync" class="fa fa-arrow-alt-circle-up" style="color: ` + colorYellow + `;"></i>`,
 	}
 )
 
@@ -0,0 +1,134 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package web
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"sort"
+)
+
+var staticContentDirs = []string{"css", "img", "js", "webfonts"}
+
+// overlayFS serves files from the override file system and falls back to the base file system for missing files.
+type overlayFS struct {
+	override fs.FS
+	base     fs.FS
+}
+
+func (overlay overlayFS) Open(name string) (fs.File, error) {
+	file, err := overlay.override.Open(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return overlay.base.Open(name)
+	}
+	return file, err
+}
+
+// ReadDir merges the entries of both file systems, so files only present in the base are not hidden by the override.
+func (overlay overlayFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	entries := map[string]fs.DirEntry{}
+	found := false
+	for _, files := range []fs.FS{overlay.base, overlay.override} {
+		dirEntries, err := fs.ReadDir(files, name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		found = true
+		for _, entry := range dirEntries {
+			entries[entry.Name()] = entry
+		}
+	}
+	if !found {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+
+	result := make([]fs.DirEntry, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, entry)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name() < result[j].Name()
+	})
+	return result, nil
+}
+
+// staticContent serves the static files and provides their URLs with a content hash for cache-busting.
+type staticContent struct {
+	files  fs.FS
+	hashes map[string]string
+}
+
+func newStaticContent(files fs.FS) (*staticContent, error) {
+	content := &staticContent{files: files, hashes: map[string]string{}}
+
+	for _, dir := range staticContentDirs {
+		if _, err := fs.Stat(files, dir); errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		err := fs.WalkDir(files, dir, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil || entry.IsDir() {
+				return err
+			}
+			data, err := fs.ReadFile(files, path)
+			if err != nil {
+				return err
+			}
+			hash := sha256.Sum256(data)
+			content.hashes["/"+path] = hex.EncodeToString(hash[:])[:12]
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("could not hash static content: %w", err)
+		}
+	}
+	return content, nil
+}
+
+// url returns the path with the content hash as query parameter, so browsers fetch changed files immediately.
+func (content *staticContent) url(path string) string {
+	if hash, found := content.hashes[path]; found {
+		return path + "?v=" + hash
+	}
+	return path
+}
+
+func (content *staticContent) configureHandlers(mux *http.ServeMux) {
+	fileServer := http.FileServer(http.FS(content.files))
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hash, found := content.hashes[r.URL.Path]; found && r.URL.Query().Get("v") == hash {
+			// the URL changes with the content, so it can be cached forever
+			w.Header().Add("Cache-Control", "max-age=31536000, public, immutable")
+			fileServer.ServeHTTP(w, r)
+			return
+		}
+		maxAgeHandler(86400, fileServer).ServeHTTP(w, r)
+	})
+
+	for _, dir := range staticContentDirs {
+		mux.Handle("/"+dir+"/", handler)
+	}
+}
@@ -0,0 +1,121 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestShouldServeEmbeddedAssetsIndependentOfWorkingDirectory(t *testing.T) {
+	// tests run in internal/web, where no web folder exists
+	webserver, err := NewWebserver(Config{})
+	if err != nil {
+		t.Fatalf("Could not create webserver from embedded assets: %v", err)
+	}
+
+	recorder := whenRequesting(webserver, "/css/main.css")
+
+	if recorder.Code != http.StatusOK || !strings.Contains(recorder.Body.String(), "#footer") {
+		t.Errorf("Embedded main.css not served! Got: %d", recorder.Code)
+	}
+}
+
+func TestShouldPreferFilesFromOverrideDir(t *testing.T) {
+	overrideDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(overrideDir, "css"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(overrideDir, "css", "main.css"), []byte("body { color: red; }"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	webserver, err := NewWebserver(Config{OverrideDir: overrideDir})
+	if err != nil {
+		t.Fatalf("Could not create webserver with override dir: %v", err)
+	}
+
+	if body := whenRequesting(webserver, "/css/main.css").Body.String(); body != "body { color: red; }" {
+		t.Errorf("Override of main.css not served! Got: %s", body)
+	}
+	if recorder := whenRequesting(webserver, "/js/main.js"); recorder.Code != http.StatusOK {
+		t.Errorf("Embedded main.js not served as fallback! Got: %d", recorder.Code)
+	}
+	if url := webserver.staticContent.url("/css/simple-datatables.css"); !strings.Contains(url, "?v=") {
+		t.Errorf("Files not overridden are missing a content hash! Got: %s", url)
+	}
+}
+
+func TestShouldAddContentHashToAssetUrls(t *testing.T) {
+	content := givenStaticContent(t, "body {}")
+	changedContent := givenStaticContent(t, "body { color: red; }")
+
+	url := content.url("/css/main.css")
+
+	if !strings.HasPrefix(url, "/css/main.css?v=") {
+		t.Errorf("Content hash not added to asset url! Got: %s", url)
+	}
+	if url == changedContent.url("/css/main.css") {
+		t.Errorf("Content hash did not change with content! Got: %s", url)
+	}
+	if unknown := content.url("/css/unknown.css"); unknown != "/css/unknown.css" {
+		t.Errorf("Url of unknown asset changed! Got: %s", unknown)
+	}
+}
+
+func TestShouldCacheHashedAssetsForever(t *testing.T) {
+	content := givenStaticContent(t, "body {}")
+	mux := http.NewServeMux()
+	content.configureHandlers(mux)
+
+	hashed := httptest.NewRecorder()
+	mux.ServeHTTP(hashed, httptest.NewRequest(http.MethodGet, content.url("/css/main.css"), nil))
+	unhashed := httptest.NewRecorder()
+	mux.ServeHTTP(unhashed, httptest.NewRequest(http.MethodGet, "/css/main.css", nil))
+
+	if cacheControl := hashed.Header().Get("Cache-Control"); cacheControl != "max-age=31536000, public, immutable" {
+		t.Errorf("Hashed asset not cached forever! Got: %s", cacheControl)
+	}
+	if cacheControl := unhashed.Header().Get("Cache-Control"); !strings.HasPrefix(cacheControl, "max-age=86400") {
+		t.Errorf("Unhashed asset not cached for a day! Got: %s", cacheControl)
+	}
+}
+
+func givenStaticContent(t *testing.T, css string) *staticContent {
+	content, err := newStaticContent(fstest.MapFS{"css/main.css": {Data: []byte(css)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return content
+}
+
+func whenRequesting(webserver *Webserver, path string) *httptest.ResponseRecorder {
+	mux := http.NewServeMux()
+	webserver.staticContent.configureHandlers(mux)
+
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+	return recorder
+}
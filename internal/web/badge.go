@@ -0,0 +1,173 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package web
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"dashboard/internal/app"
+	"dashboard/internal/forge"
+)
+
+const badgeTemplate = `<svg xmlns="http://www.w3.org/2000/svg" width="%[1]d" height="20" role="img" aria-label="%[4]s: %[5]s">` +
+	`<title>%[4]s: %[5]s</title>` +
+	`<linearGradient id="s" x2="0" y2="100%%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>` +
+	`<clipPath id="r"><rect width="%[1]d" height="20" rx="3" fill="#fff"/></clipPath>` +
+	`<g clip-path="url(#r)"><rect width="%[2]d" height="20" fill="#555"/><rect x="%[2]d" width="%[3]d" height="20" fill="%[6]s"/><rect width="%[1]d" height="20" fill="url(#s)"/></g>` +
+	`<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">` +
+	`<text x="%[7]d" y="14">%[4]s</text><text x="%[8]d" y="14">%[5]s</text></g></svg>`
+
+type badge struct {
+	label   string
+	message string
+	color   string
+}
+
+// svg renders the badge in the style of shields.io. The width of the text is estimated, since Verdana at 11px
+// averages about 7px per character.
+func (b badge) svg() string {
+	labelWidth := textWidth(b.label)
+	messageWidth := textWidth(b.message)
+
+	return fmt.Sprintf(badgeTemplate, labelWidth+messageWidth, labelWidth, messageWidth,
+		escapeXml(b.label), escapeXml(b.message), b.color, labelWidth/2, labelWidth+messageWidth/2)
+}
+
+func textWidth(text string) int {
+	return len([]rune(text))*7 + 10
+}
+
+// configureBadgeEndpoints serves /badge/<namespace>/<name>/{health,sync,version} for single applications and
+// /badge/environment for all applications. The label can be changed with the query parameter label.
+func (web *Webserver) configureBadgeEndpoints(mux *http.ServeMux, syncResult *app.ApplicationsSyncResult) {
+	mux.HandleFunc("/badge/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/badge/"), "/")
+
+		syncResult.RLock()
+		result, found := badgeFor(parts, syncResult)
+		syncResult.RUnlock()
+
+		if label := r.URL.Query().Get("label"); label != "" {
+			result.label = label
+		}
+
+		w.Header().Set("Content-Type", "image/svg+xml")
+		// badges are embedded in READMEs, where proxies like GitHub's camo would otherwise cache them for a long time
+		w.Header().Set("Cache-Control", "no-cache, max-age=0")
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+		}
+		_, _ = w.Write([]byte(result.svg()))
+	})
+}
+
+func badgeFor(parts []string, syncResult *app.ApplicationsSyncResult) (badge, bool) {
+	if len(parts) == 1 && parts[0] == "environment" {
+		return environmentBadge(syncResult), true
+	}
+	if len(parts) != 3 {
+		return badge{label: "badge", message: "not found", color: colorGrey}, false
+	}
+
+	item, found := findApplication(syncResult.Res, parts[0], parts[1])
+	if !found {
+		return badge{label: parts[2], message: "not found", color: colorGrey}, false
+	}
+
+	switch parts[2] {
+	case "health":
+		return statusBadge("health", item.Status.Health.Status, argoHealthColors), true
+	case "sync":
+		return statusBadge("sync", item.Status.Sync.Status, argoSyncStatusColors), true
+	case "version":
+		return badge{label: "version", message: valueOrUnknown(deployedVersion(item)), color: colorBlue}, true
+	default:
+		return badge{label: parts[2], message: "not found", color: colorGrey}, false
+	}
+}
+
+func environmentBadge(syncResult *app.ApplicationsSyncResult) badge {
+	if !syncResult.InitialSync {
+		return badge{label: syncResult.Environment, message: "unknown", color: colorGrey}
+	}
+
+	total, healthy, degraded := 0, 0, 0
+	for _, item := range syncResult.Res.Items {
+		total++
+		switch item.Status.Health.Status {
+		case "Healthy":
+			healthy++
+		case "Degraded":
+			degraded++
+		}
+	}
+
+	color := argoHealthColors["Progressing"]
+	if total == 0 {
+		color = colorGrey
+	} else if healthy == total {
+		color = argoHealthColors["Healthy"]
+	} else if degraded > 0 {
+		color = argoHealthColors["Degraded"]
+	}
+	return badge{label: syncResult.Environment, message: fmt.Sprintf("%d/%d healthy", healthy, total), color: color}
+}
+
+func statusBadge(label string, status string, colors map[string]string) badge {
+	color, found := colors[status]
+	if !found {
+		color = defaultArgoStatusColor
+	}
+	return badge{label: label, message: valueOrUnknown(status), color: color}
+}
+
+// deployedVersion shortens commit hashes like git does.
+func deployedVersion(item app.Item) string {
+	version := item.DeployedVersion()
+	if forge.IsCommitHash(version) {
+		return version[:7]
+	}
+	return version
+}
+
+func findApplication(applications app.Applications, namespace string, name string) (app.Item, bool) {
+	for _, item := range applications.Items {
+		if item.Metadata.Namespace == namespace && item.Metadata.Name == name {
+			return item, true
+		}
+	}
+	return app.Item{}, false
+}
+
+func valueOrUnknown(value string) string {
+	if value == "" {
+		return "unknown"
+	}
+	return value
+}
+
+func escapeXml(value string) string {
+	var builder strings.Builder
+	_ = xml.EscapeText(&builder, []byte(value))
+	return builder.String()
+}
@@ -0,0 +1,115 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package web
+
+import (
+	"dashboard/internal/app"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestShouldRenderHealthBadgeInArgoColour(t *testing.T) {
+	recorder := whenRequestingBadge(givenBadgeSyncResult(), "/badge/argocd/irs/health")
+
+	thenBadgeIs(recorder, http.StatusOK, "health: Healthy", colorGreen, t)
+}
+
+func TestShouldRenderSyncBadge(t *testing.T) {
+	recorder := whenRequestingBadge(givenBadgeSyncResult(), "/badge/argocd/edc/sync")
+
+	thenBadgeIs(recorder, http.StatusOK, "sync: OutOfSync", colorYellow, t)
+}
+
+func TestShouldRenderShortenedCommitAsVersionBadge(t *testing.T) {
+	recorder := whenRequestingBadge(givenBadgeSyncResult(), "/badge/argocd/edc/version")
+
+	thenBadgeIs(recorder, http.StatusOK, "version: 3d7377d", colorBlue, t)
+}
+
+func TestShouldRenderChartVersionAsVersionBadge(t *testing.T) {
+	recorder := whenRequestingBadge(givenBadgeSyncResult(), "/badge/argocd/irs/version")
+
+	thenBadgeIs(recorder, http.StatusOK, "version: 2.0.0", colorBlue, t)
+}
+
+func TestShouldRenderEnvironmentBadge(t *testing.T) {
+	recorder := whenRequestingBadge(givenBadgeSyncResult(), "/badge/environment")
+
+	thenBadgeIs(recorder, http.StatusOK, "int: 1/2 healthy", colorRed, t)
+}
+
+func TestShouldRenderUnknownEnvironmentBadgeBeforeInitialSync(t *testing.T) {
+	recorder := whenRequestingBadge(&app.ApplicationsSyncResult{Environment: "int"}, "/badge/environment")
+
+	thenBadgeIs(recorder, http.StatusOK, "int: unknown", colorGrey, t)
+}
+
+func TestShouldOverrideBadgeLabel(t *testing.T) {
+	recorder := whenRequestingBadge(givenBadgeSyncResult(), "/badge/argocd/irs/health?label=IRS%20%26%20co")
+
+	thenBadgeIs(recorder, http.StatusOK, "IRS &amp; co: Healthy", colorGreen, t)
+}
+
+func TestShouldRenderNotFoundBadgeForUnknownApplications(t *testing.T) {
+	for _, path := range []string{"/badge/argocd/unknown/health", "/badge/argocd/irs/size", "/badge/irs"} {
+		recorder := whenRequestingBadge(givenBadgeSyncResult(), path)
+
+		if recorder.Code != http.StatusNotFound || !strings.Contains(recorder.Body.String(), ": not found</title>") {
+			t.Errorf("Expected not found badge for %s! Got: %d %s", path, recorder.Code, recorder.Body.String())
+		}
+	}
+}
+
+func givenBadgeSyncResult() *app.ApplicationsSyncResult {
+	irs := app.Item{}
+	irs.Metadata = app.Metadata{Namespace: "argocd", Name: "irs"}
+	irs.Status.Health.Status = "Healthy"
+	irs.Status.Sync.Status = "Synced"
+	irs.Status.History = []app.History{{Id: 1, Revision: "1.0.0"}, {Id: 2, Revision: "2.0.0"}}
+
+	edc := app.Item{}
+	edc.Metadata = app.Metadata{Namespace: "argocd", Name: "edc"}
+	edc.Status.Health.Status = "Degraded"
+	edc.Status.Sync.Status = "OutOfSync"
+	edc.Status.History = []app.History{{Id: 1, Revision: "3d7377d0af2683eb89f7c572d7f01fa794260e55"}}
+
+	return &app.ApplicationsSyncResult{Res: app.Applications{Items: []app.Item{irs, edc}}, Environment: "int", InitialSync: true}
+}
+
+func whenRequestingBadge(syncResult *app.ApplicationsSyncResult, path string) *httptest.ResponseRecorder {
+	mux := http.NewServeMux()
+	(&Webserver{}).configureBadgeEndpoints(mux, syncResult)
+
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+	return recorder
+}
+
+func thenBadgeIs(recorder *httptest.ResponseRecorder, status int, title string, color string, t *testing.T) {
+	body := recorder.Body.String()
+	if recorder.Code != status || recorder.Header().Get("Content-Type") != "image/svg+xml" {
+		t.Errorf("Badge not served! Got: %d %s", recorder.Code, recorder.Header().Get("Content-Type"))
+	}
+	if !strings.Contains(body, "<title>"+title+"</title>") || !strings.Contains(body, `fill="`+color+`"`) {
+		t.Errorf("Badge not rendered correctly! \nexpected: %s in %s \nGot: %s", title, color, body)
+	}
+}
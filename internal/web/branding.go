@@ -0,0 +1,57 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package web
+
+// Branding customizes the look of the dashboard for operators other than Eclipse Tractus-X.
+type Branding struct {
+	// Title of the browser tab
+	Title string
+	// Name shown next to the logo in the header
+	Name string
+	// LogoUrl is either the path of an asset, which may be provided through the override dir, or an absolute URL
+	LogoUrl               string
+	PrimaryColor          string
+	BackgroundColor       string
+	HeaderBackgroundColor string
+	TextColor             string
+	HeaderLinks           []Link
+	FooterText            string
+	FooterLink            Link
+	// Announcement is shown as a banner on top of the dashboard, e.g. "Release freeze until Friday"
+	Announcement string
+}
+
+type Link struct {
+	Name string
+	Url  string
+}
+
+func DefaultBranding() Branding {
+	return Branding{
+		Title:   "Version Dashboard",
+		Name:    "Eclipse Tractus-X",
+		LogoUrl: "/img/logo_tractus-x.svg",
+		HeaderLinks: []Link{
+			{Name: "GitHub", Url: "https://github.com/eclipse-tractusx/eclipse-tractusx.github.io"},
+		},
+		FooterText: "Copyright © 2023",
+		FooterLink: Link{Name: "Eclipse Tractus-X", Url: "https://projects.eclipse.org/projects/automotive.tractusx"},
+	}
+}
@@ -0,0 +1,101 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package web
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"dashboard/internal/app"
+	"dashboard/internal/export"
+)
+
+type exportFormat struct {
+	contentType string
+	write       func(w io.Writer, title string, rows []export.Row) error
+}
+
+var exportFormats = map[string]exportFormat{
+	"csv": {
+		contentType: "text/csv; charset=utf-8",
+		write: func(w io.Writer, title string, rows []export.Row) error {
+			return export.WriteCsv(w, rows)
+		},
+	},
+	"xlsx": {
+		contentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+		write: func(w io.Writer, title string, rows []export.Row) error {
+			return export.WriteXlsx(w, rows)
+		},
+	},
+	"md": {
+		contentType: "text/markdown; charset=utf-8",
+		write:       export.WriteMarkdown,
+	},
+	"adoc": {
+		contentType: "text/asciidoc; charset=utf-8",
+		write:       export.WriteAsciiDoc,
+	},
+}
+
+// configureExportEndpoints serves /export/applications.<format>, filtered by the query parameters q, namespace,
+// health, sync and owner.
+func (web *Webserver) configureExportEndpoints(mux *http.ServeMux, syncResult *app.ApplicationsSyncResult) {
+	mux.HandleFunc("/export/", func(w http.ResponseWriter, r *http.Request) {
+		name := path.Base(r.URL.Path)
+		extension := strings.TrimPrefix(path.Ext(name), ".")
+		format, found := exportFormats[extension]
+		if !found || strings.TrimSuffix(name, "."+extension) != "applications" {
+			http.NotFound(w, r)
+			return
+		}
+
+		filter := export.Filter{
+			Query:     r.URL.Query().Get("q"),
+			Namespace: r.URL.Query().Get("namespace"),
+			Health:    r.URL.Query().Get("health"),
+			Sync:      r.URL.Query().Get("sync"),
+			Owner:     r.URL.Query().Get("owner"),
+		}
+
+		syncResult.RLock()
+		rows := export.Rows(syncResult.Res, filter)
+		environment := syncResult.Environment
+		lastSync := syncResult.LastSync
+		syncResult.RUnlock()
+
+		title := fmt.Sprintf("Installed applications on %s (%s)", environment, lastSync.Format("2006-01-02 15:04 MST"))
+		var content bytes.Buffer
+		if err := format.write(&content, title, rows); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		fileName := fmt.Sprintf("applications-%s-%s.%s", environment, lastSync.Format("2006-01-02"), extension)
+		w.Header().Set("Content-Type", format.contentType)
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName))
+		w.Header().Set("Cache-Control", "no-cache, no-store, no-transform, must-revalidate, private, max-age=0")
+		_, _ = content.WriteTo(w)
+	})
+}
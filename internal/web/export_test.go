@@ -0,0 +1,69 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package web
+
+import (
+	"dashboard/internal/app"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestShouldExportFilteredApplications(t *testing.T) {
+	irs := app.Item{}
+	irs.Metadata.Name = "irs"
+	edc := app.Item{}
+	edc.Metadata.Name = "edc"
+	syncResult := &app.ApplicationsSyncResult{
+		Res:         app.Applications{Items: []app.Item{irs, edc}},
+		Environment: "int",
+		LastSync:    parseTime("2022-09-18T08:00:00Z"),
+	}
+
+	recorder := whenExporting(syncResult, "/export/applications.csv?q=irs")
+
+	if recorder.Code != http.StatusOK || recorder.Header().Get("Content-Type") != "text/csv; charset=utf-8" {
+		t.Fatalf("CSV export failed! Got: %d %s", recorder.Code, recorder.Header().Get("Content-Type"))
+	}
+	if disposition := recorder.Header().Get("Content-Disposition"); disposition != `attachment; filename="applications-int-2022-09-18.csv"` {
+		t.Errorf("Wrong file name for export! Got: %s", disposition)
+	}
+	if body := recorder.Body.String(); !strings.Contains(body, "\nirs,") || strings.Contains(body, "\nedc,") {
+		t.Errorf("Export not filtered! Got: %s", body)
+	}
+}
+
+func TestShouldRejectUnknownExportFormats(t *testing.T) {
+	for _, path := range []string{"/export/applications.pdf", "/export/secrets.csv"} {
+		if recorder := whenExporting(&app.ApplicationsSyncResult{}, path); recorder.Code != http.StatusNotFound {
+			t.Errorf("Expected 404 for %s! Got: %d", path, recorder.Code)
+		}
+	}
+}
+
+func whenExporting(syncResult *app.ApplicationsSyncResult, path string) *httptest.ResponseRecorder {
+	mux := http.NewServeMux()
+	(&Webserver{}).configureExportEndpoints(mux, syncResult)
+
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+	return recorder
+}
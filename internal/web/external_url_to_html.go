@@ -0,0 +1,38 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package web
+
+import (
+	"html"
+	"strings"
+)
+
+// externalUrlToHtml links an external URL and marks the ones served with TLS. URLs are partly discovered from
+// routing objects in the cluster, so they are escaped and only http(s) URLs are linked.
+func externalUrlToHtml(url string) string {
+	escaped := html.EscapeString(url)
+	if strings.HasPrefix(url, "https://") {
+		return `<i class="fa fa-lock" title="TLS"></i> <a href="` + escaped + `" target="_blank">` + escaped + `</a>`
+	}
+	if strings.HasPrefix(url, "http://") {
+		return `<a href="` + escaped + `" target="_blank">` + escaped + `</a>`
+	}
+	return escaped
+}
@@ -0,0 +1,51 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package web
+
+import (
+	"testing"
+)
+
+func TestShouldMarkExternalUrlsServedWithTls(t *testing.T) {
+	renderedHtml := externalUrlToHtml("https://edc.example.org/api")
+
+	expected := `<i class="fa fa-lock" title="TLS"></i> <a href="https://edc.example.org/api" target="_blank">https://edc.example.org/api</a>`
+	if renderedHtml != expected {
+		t.Errorf("External URL not rendered correctly! \nexpected: %s \nGot: %s", expected, renderedHtml)
+	}
+}
+
+func TestShouldLinkExternalUrlsWithoutTls(t *testing.T) {
+	renderedHtml := externalUrlToHtml("http://edc.internal")
+
+	expected := `<a href="http://edc.internal" target="_blank">http://edc.internal</a>`
+	if renderedHtml != expected {
+		t.Errorf("External URL not rendered correctly! \nexpected: %s \nGot: %s", expected, renderedHtml)
+	}
+}
+
+func TestShouldNotLinkExternalUrlsWithOtherSchemes(t *testing.T) {
+	renderedHtml := externalUrlToHtml(`javascript:alert("x")`)
+
+	expected := `javascript:alert(&#34;x&#34;)`
+	if renderedHtml != expected {
+		t.Errorf("External URL not rendered correctly! \nexpected: %s \nGot: %s", expected, renderedHtml)
+	}
+}
@@ -0,0 +1,79 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"dashboard/internal/app"
+)
+
+type readiness struct {
+	Ready         bool       `json:"ready"`
+	Reason        string     `json:"reason,omitempty"`
+	LastSync      *time.Time `json:"lastSync,omitempty"`
+	LastSyncError string     `json:"lastSyncError,omitempty"`
+}
+
+func (web *Webserver) configureHealthEndpoints(mux *http.ServeMux, syncResult *app.ApplicationsSyncResult) {
+	liveness := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "no-cache, no-store, no-transform, must-revalidate, private, max-age=0")
+		w.WriteHeader(http.StatusNoContent)
+	}
+	mux.HandleFunc("/livez", liveness)
+	// kept for probes configured before /livez and /readyz existed
+	mux.HandleFunc("/healthz", liveness)
+
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		syncResult.RLock()
+		result := readinessOf(syncResult, web.config.ReadinessMaxStaleness, currentTime())
+		syncResult.RUnlock()
+
+		status := http.StatusOK
+		if !result.Ready {
+			status = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Cache-Control", "no-cache, no-store, no-transform, must-revalidate, private, max-age=0")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(result)
+	})
+}
+
+// readinessOf requires a completed initial sync and, if maxStaleness is set, a successful sync within maxStaleness.
+func readinessOf(syncResult *app.ApplicationsSyncResult, maxStaleness time.Duration, now time.Time) readiness {
+	if !syncResult.InitialSync {
+		return readiness{Reason: "initial sync not completed", LastSyncError: syncResult.LastSyncError}
+	}
+
+	lastSync := syncResult.LastSync
+	result := readiness{Ready: true, LastSync: &lastSync, LastSyncError: syncResult.LastSyncError}
+
+	if age := now.Sub(lastSync); maxStaleness > 0 && age > maxStaleness {
+		result.Ready = false
+		result.Reason = fmt.Sprintf("last successful sync %s ago exceeds the staleness threshold of %s",
+			age.Round(time.Second), maxStaleness)
+	}
+	return result
+}
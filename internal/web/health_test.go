@@ -0,0 +1,114 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package web
+
+import (
+	"dashboard/internal/app"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestShouldNotBeReadyBeforeInitialSync(t *testing.T) {
+	givenCurrentTime("2022-09-18T08:00:00Z")
+	syncResult := &app.ApplicationsSyncResult{InitialSync: false, LastSyncError: "connection refused"}
+
+	status, body := whenRequestingReadiness(syncResult, 15*time.Minute)
+
+	if status != http.StatusServiceUnavailable || body.Ready || body.Reason != "initial sync not completed" {
+		t.Errorf("Expected not ready before initial sync! Got: %d %+v", status, body)
+	}
+	if body.LastSyncError != "connection refused" {
+		t.Errorf("Last sync error not reported! Got: %s", body.LastSyncError)
+	}
+}
+
+func TestShouldBeReadyAfterRecentSync(t *testing.T) {
+	givenCurrentTime("2022-09-18T08:00:00Z")
+	syncResult := &app.ApplicationsSyncResult{InitialSync: true, LastSync: parseTime("2022-09-18T07:55:00Z")}
+
+	status, body := whenRequestingReadiness(syncResult, 15*time.Minute)
+
+	if status != http.StatusOK || !body.Ready || body.Reason != "" {
+		t.Errorf("Expected ready after recent sync! Got: %d %+v", status, body)
+	}
+}
+
+func TestShouldNotBeReadyWithStaleData(t *testing.T) {
+	givenCurrentTime("2022-09-18T08:00:00Z")
+	syncResult := &app.ApplicationsSyncResult{InitialSync: true, LastSync: parseTime("2022-09-18T07:00:00Z")}
+
+	status, body := whenRequestingReadiness(syncResult, 15*time.Minute)
+
+	expectedReason := "last successful sync 1h0m0s ago exceeds the staleness threshold of 15m0s"
+	if status != http.StatusServiceUnavailable || body.Ready || body.Reason != expectedReason {
+		t.Errorf("Expected not ready with stale data! \nexpected: %s \nGot: %d %+v", expectedReason, status, body)
+	}
+}
+
+func TestShouldIgnoreStalenessIfDisabled(t *testing.T) {
+	givenCurrentTime("2022-09-18T08:00:00Z")
+	syncResult := &app.ApplicationsSyncResult{InitialSync: true, LastSync: parseTime("2022-09-17T07:00:00Z")}
+
+	status, _ := whenRequestingReadiness(syncResult, 0)
+
+	if status != http.StatusOK {
+		t.Errorf("Expected ready with disabled staleness check! Got: %d", status)
+	}
+}
+
+func TestShouldAlwaysBeLive(t *testing.T) {
+	mux := http.NewServeMux()
+	(&Webserver{}).configureHealthEndpoints(mux, &app.ApplicationsSyncResult{})
+
+	for _, path := range []string{"/livez", "/healthz"} {
+		recorder := httptest.NewRecorder()
+		mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+
+		if recorder.Code != http.StatusNoContent {
+			t.Errorf("Expected %s to return 204! Got: %d", path, recorder.Code)
+		}
+	}
+}
+
+func whenRequestingReadiness(syncResult *app.ApplicationsSyncResult, maxStaleness time.Duration) (int, readiness) {
+	mux := http.NewServeMux()
+	(&Webserver{config: Config{ReadinessMaxStaleness: maxStaleness}}).configureHealthEndpoints(mux, syncResult)
+
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	var body readiness
+	_ = json.Unmarshal(recorder.Body.Bytes(), &body)
+	return recorder.Code, body
+}
+
+func givenCurrentTime(value string) {
+	currentTime = func() time.Time {
+		return parseTime(value)
+	}
+}
+
+func parseTime(value string) time.Time {
+	t, _ := time.Parse(time.RFC3339, value)
+	return t
+}
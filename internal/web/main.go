@@ -20,43 +20,122 @@
 package web
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"log"
 	"net/http"
 	"os"
 	"sort"
-	"strings"
 	"text/template"
 	"time"
 
 	"dashboard/internal/app"
+	"dashboard/internal/forge"
+	"dashboard/internal/release"
+	assets "dashboard/web"
 )
 
+type Config struct {
+	// OverrideDir contains files replacing the embedded assets and templates, e.g. for custom theming
+	OverrideDir string
+	// ShutdownTimeout is the time in-flight requests get to finish on shutdown
+	ShutdownTimeout time.Duration
+	// ReadinessMaxStaleness is the maximum age of the last successful sync for the dashboard to be ready
+	ReadinessMaxStaleness time.Duration
+	Branding              Branding
+	// ForgeHosts maps custom hostnames to the forge they run, i.e. GitHub Enterprise or self-hosted GitLab
+	ForgeHosts map[string]forge.Kind
+	// ReleaseManifest lists the product versions of the release the environment should be on, if any
+	ReleaseManifest *release.Manifest
+}
+
+// page is the data the index template is rendered with.
+type page struct {
+	*app.ApplicationsSyncResult
+	Branding Branding
+	// Release is the conformance with the release manifest, nil if none is configured
+	Release *release.Report
+}
+
 type Webserver struct {
-	errorPage []byte
+	errorPage     []byte
+	config        Config
+	files         fs.FS
+	staticContent *staticContent
+	template      *template.Template
+	forgeResolver *forge.Resolver
 }
 
-func NewWebserver() *Webserver {
-	errorPage, err := os.ReadFile("web/error.html")
+func NewWebserver(config Config) (*Webserver, error) {
+	var files fs.FS = assets.Assets
+	if config.OverrideDir != "" {
+		files = overlayFS{override: os.DirFS(config.OverrideDir), base: files}
+	}
+
+	errorPage, err := fs.ReadFile(files, "error.html")
 	if err != nil {
-		panic(err)
+		return nil, err
+	}
+	staticContent, err := newStaticContent(files)
+	if err != nil {
+		return nil, err
 	}
 
-	return &Webserver{errorPage: errorPage}
+	web := &Webserver{
+		errorPage:     errorPage,
+		config:        config,
+		files:         files,
+		staticContent: staticContent,
+		forgeResolver: forge.NewResolver(config.ForgeHosts),
+	}
+	if web.template, err = web.createHtmlTemplate(); err != nil {
+		return nil, err
+	}
+	return web, nil
 }
 
-func (web *Webserver) Start(port int, syncResult *app.ApplicationsSyncResult) {
-	configureStaticContentServe()
-	configureHealthEndpoint()
+func (web *Webserver) Start(ctx context.Context, port int, syncResult *app.ApplicationsSyncResult) error {
+	mux := http.NewServeMux()
+	web.staticContent.configureHandlers(mux)
+	web.configureHealthEndpoints(mux, syncResult)
+	web.configureExportEndpoints(mux, syncResult)
+	web.configureBadgeEndpoints(mux, syncResult)
+	web.configureApplicationEndpoints(mux, syncResult)
+	web.configureAboutEndpoint(mux, syncResult)
+
+	web.configureRootHandler(mux, web.template, syncResult)
+
+	server := &http.Server{Addr: fmt.Sprintf(":%d", port), Handler: mux}
+	serverError := make(chan error, 1)
+	go func() {
+		serverError <- server.ListenAndServe()
+	}()
+	log.Printf("Listening on port :%d\n", port)
 
-	web.configureRootHandler(createHtmlTemplate(), syncResult)
+	select {
+	case err := <-serverError:
+		return err
+	case <-ctx.Done():
+	}
 
-	log.Printf("Listening on port :%d\n", port)
-	log.Fatal(http.ListenAndServe(fmt.Sprintf(":%d", port), nil))
+	log.Println("Shutting down webserver")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), web.config.ShutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("could not shut down webserver gracefully: %w", err)
+	}
+	if err := <-serverError; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
 }
 
-func (web *Webserver) configureRootHandler(template *template.Template, syncResult *app.ApplicationsSyncResult) {
-	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+func (web *Webserver) configureRootHandler(mux *http.ServeMux, template *template.Template, syncResult *app.ApplicationsSyncResult) {
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 
 		if r.RequestURI != "/" && r.RequestURI != "/index" && r.RequestURI != "/index.html" {
 			w.WriteHeader(http.StatusNotFound)
@@ -66,24 +145,43 @@ func (web *Webserver) configureRootHandler(template *template.Template, syncResu
 			return
 		}
 
-		w.Header().Set("Cache-Control", "no-cache, no-store, no-transform, must-revalidate, private, max-age=0")
+		// Render into a buffer first, so slow clients don't block the sync loop from updating the result
+		var rendered bytes.Buffer
+		syncResult.RLock()
+		err := template.ExecuteTemplate(&rendered, "index.html", web.page(syncResult))
+		syncResult.RUnlock()
 
-		w.WriteHeader(http.StatusOK)
-
-		if err := template.ExecuteTemplate(w, "index.html", syncResult); err != nil {
+		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
+
+		w.Header().Set("Cache-Control", "no-cache, no-store, no-transform, must-revalidate, private, max-age=0")
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = rendered.WriteTo(w)
 	})
 }
 
-func createHtmlTemplate() *template.Template {
-	templates := template.Must(template.New("index.html").Funcs(template.FuncMap{
-		"argoHealth": argoHealthToHtmlFunc(),
-		"argoSync":   argoSyncStatusToHtmlFunc(),
-		"fixGithubUrl": func(url string) string {
-			return strings.TrimSuffix(strings.ReplaceAll(url, "git@github.com:", "https://github.com/"), ".git")
-		},
+func (web *Webserver) page(syncResult *app.ApplicationsSyncResult) page {
+	result := page{ApplicationsSyncResult: syncResult, Branding: web.config.Branding}
+	if web.config.ReleaseManifest != nil {
+		report := web.config.ReleaseManifest.Check(syncResult.Res.Items)
+		result.Release = &report
+	}
+	return result
+}
+
+func (web *Webserver) createHtmlTemplate() (*template.Template, error) {
+	return template.New("index.html").Funcs(template.FuncMap{
+		"asset":          web.staticContent.url,
+		"argoHealth":     argoHealthToHtmlFunc(),
+		"argoSync":       argoSyncStatusToHtmlFunc(),
+		"argoProblems":   argoProblemsToHtml,
+		"argoConditions": argoConditionsToHtml,
+		"treeUrl":        treeUrlFunc(web.forgeResolver),
 		"lastAppSyncShort": func(history []app.History) string {
+			history = append([]app.History(nil), history...)
 			sort.Slice(history, func(i, j int) bool {
 				return history[i].Id > history[j].Id
 			})
@@ -101,53 +199,24 @@ func createHtmlTemplate() *template.Template {
 
 			return fmt.Sprint(duration)
 		},
-		"lastAppSyncLong": lastAppSyncToHtmlFunc(),
+		"lastAppSyncLong": lastAppSyncToHtmlFunc(web.forgeResolver),
 		"lastSync": func(lastUpdate time.Time) string {
 
 			duration := time.Now().Sub(lastUpdate).Round(time.Second)
 
 			return fmt.Sprint(duration)
 		},
-		"ignoreNamespace": func(ignoreNamespace map[string]bool) string {
-
-			if len(ignoreNamespace) == 0 {
-				return "No Namespaces are set to be ignored."
-			}
-
-			result := fmt.Sprintf("(%d): ", len(ignoreNamespace))
-			for k := range ignoreNamespace {
-				result += k
-
-				result += ", "
-			}
-
-			return strings.TrimSuffix(result, ", ")
-		},
-		"image": containerImageToHtmlFunc(),
-	}).ParseFiles("./web/template/index.html"))
-
-	return templates
-}
-
-func configureStaticContentServe() {
-	http.Handle("/css/", maxAgeHandler(86400, http.StripPrefix("/css/",
-		http.FileServer(http.Dir("./web/css")))))
-
-	http.Handle("/img/", maxAgeHandler(86400, http.StripPrefix("/img/",
-		http.FileServer(http.Dir("./web/img")))))
-
-	http.Handle("/webfonts/", maxAgeHandler(86400, http.StripPrefix("/webfonts/",
-		http.FileServer(http.Dir("./web/webfonts")))))
-
-	http.Handle("/js/", maxAgeHandler(86400, http.StripPrefix("/js/",
-		http.FileServer(http.Dir("./web/js")))))
-}
-
-func configureHealthEndpoint() {
-	http.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Cache-Control", "no-cache, no-store, no-transform, must-revalidate, private, max-age=0")
-		w.WriteHeader(http.StatusNoContent)
-	})
+		"image":          containerImageToHtmlFunc(),
+		"releaseStatus":  releaseStatusToHtml,
+		"releaseSummary": releaseSummaryToHtml,
+		"applicationUrl": applicationUrl,
+		"resourceCounts": resourceCountsToHtml,
+		"podStatus":      podStatusToHtml,
+		"externalUrl":    externalUrlToHtml,
+		"probeStatus":    probeStatusToHtml,
+		"probeSummary":   probeSummaryToHtml,
+		"owner":          ownerToHtml,
+	}).ParseFS(web.files, "template/*.html")
 }
 
 func maxAgeHandler(seconds int, h http.Handler) http.Handler {
@@ -0,0 +1,152 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package web
+
+import (
+	"dashboard/internal/app"
+	"dashboard/internal/release"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestShouldRenderIndexPage(t *testing.T) {
+	item := app.Item{}
+	item.Metadata.Name = "irs"
+	item.Spec.Destination.Namespace = "product-irs"
+	syncResult := &app.ApplicationsSyncResult{Res: app.Applications{Items: []app.Item{item}}, Environment: "int"}
+
+	recorder := whenRequestingPage(Config{Branding: DefaultBranding()}, syncResult, "/", t)
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Index page not rendered! Got: %d %s", recorder.Code, recorder.Body.String())
+	}
+	for _, expected := range []string{"Environment: int", "product-irs", `href="/css/main.css?v=`} {
+		if !strings.Contains(recorder.Body.String(), expected) {
+			t.Errorf("Rendered index page does not contain %q!", expected)
+		}
+	}
+}
+
+func TestShouldRenderWarningCountLinkingToEvents(t *testing.T) {
+	item := app.Item{}
+	item.Metadata = app.Metadata{Name: "irs", Namespace: "argocd"}
+	item.Events = []app.Event{{Reason: "BackOff"}, {Reason: "FailedMount"}}
+	syncResult := &app.ApplicationsSyncResult{Res: app.Applications{Items: []app.Item{item}}}
+
+	recorder := whenRequestingPage(Config{Branding: DefaultBranding()}, syncResult, "/", t)
+
+	expected := `<a class="event-count" href="/applications/argocd/irs#events" title="Recent Warning events">2 warnings</a>`
+	if !strings.Contains(recorder.Body.String(), expected) {
+		t.Errorf("Rendered index page does not contain %q!", expected)
+	}
+}
+
+func TestShouldRenderOwnerColumn(t *testing.T) {
+	item := app.Item{}
+	item.Metadata = app.Metadata{Name: "irs", Namespace: "argocd"}
+	item.Owner = []app.OwnerField{{Key: "team", Value: "traceability"}}
+	syncResult := &app.ApplicationsSyncResult{Res: app.Applications{Items: []app.Item{item}}}
+
+	recorder := whenRequestingPage(Config{Branding: DefaultBranding()}, syncResult, "/", t)
+
+	expected := `<ul class="owner"><li>team: traceability</li></ul>`
+	if !strings.Contains(recorder.Body.String(), expected) {
+		t.Errorf("Rendered index page does not contain %q!", expected)
+	}
+}
+
+func TestShouldRenderCustomBranding(t *testing.T) {
+	branding := Branding{
+		Title:        "Partner Dashboard",
+		Name:         "Partner & Co",
+		LogoUrl:      "https://partner.example/logo.svg",
+		PrimaryColor: "#0055aa",
+		HeaderLinks:  []Link{{Name: "Docs", Url: "https://partner.example/docs"}},
+		FooterText:   "Operated by Partner",
+		Announcement: "Release freeze until Friday",
+	}
+
+	recorder := whenRequestingPage(Config{Branding: branding}, &app.ApplicationsSyncResult{}, "/", t)
+
+	for _, expected := range []string{
+		"<title>Partner Dashboard</title>",
+		"<span>Partner &amp; Co</span>",
+		`<img src="https://partner.example/logo.svg"`,
+		"--primary-color: #0055aa;",
+		`<a href="https://partner.example/docs" target="_blank">`,
+		`<div id="announcement">Release freeze until Friday</div>`,
+		`<div id="footer">Operated by Partner.</div>`,
+	} {
+		if !strings.Contains(recorder.Body.String(), expected) {
+			t.Errorf("Rendered index page does not contain %q!", expected)
+		}
+	}
+	if strings.Contains(recorder.Body.String(), "Eclipse Tractus-X") {
+		t.Errorf("Rendered index page still contains the default branding!")
+	}
+}
+
+func TestShouldRenderReleaseConformance(t *testing.T) {
+	item := app.Item{}
+	item.Metadata.Name = "portal"
+	item.Metadata.Namespace = "argocd"
+	item.Spec.Source = app.Source{RepoUrl: "https://eclipse-tractusx.github.io/charts/dev", Chart: "portal", TargetRevision: "1.7.0"}
+	syncResult := &app.ApplicationsSyncResult{Res: app.Applications{Items: []app.Item{item}}}
+	manifest := &release.Manifest{Release: "24.03", Products: []release.Product{
+		{Name: "portal", Version: "1.8.0"},
+		{Name: "semantic-hub", Version: "0.2.0"},
+	}}
+
+	recorder := whenRequestingPage(Config{Branding: DefaultBranding(), ReleaseManifest: manifest}, syncResult, "/", t)
+
+	for _, expected := range []string{
+		`<summary>Release 24.03: <span style="color: ` + colorYellow + `;">1 behind</span>, <span style="color: ` + colorRed + `;">1 missing</span></summary>`,
+		`<td>argocd/portal</td>`,
+		`Release: <span class="release-status" title="chart 1.7.0 is behind 1.8.0" style="color: ` + colorYellow + `;">behind</span>`,
+		`<td>semantic-hub</td>`,
+	} {
+		if !strings.Contains(recorder.Body.String(), expected) {
+			t.Errorf("Rendered index page does not contain %q!", expected)
+		}
+	}
+}
+
+func TestShouldRenderErrorPageForUnknownPaths(t *testing.T) {
+	recorder := whenRequestingPage(Config{}, &app.ApplicationsSyncResult{}, "/unknown", t)
+
+	if recorder.Code != http.StatusNotFound || !strings.Contains(recorder.Body.String(), "404") {
+		t.Errorf("Error page not rendered for unknown path! Got: %d", recorder.Code)
+	}
+}
+
+func whenRequestingPage(config Config, syncResult *app.ApplicationsSyncResult, path string, t *testing.T) *httptest.ResponseRecorder {
+	webserver, err := NewWebserver(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mux := http.NewServeMux()
+	webserver.configureRootHandler(mux, webserver.template, syncResult)
+
+	recorder := httptest.NewRecorder()
+	mux.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+	return recorder
+}
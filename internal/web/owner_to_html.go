@@ -0,0 +1,39 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package web
+
+import (
+	"dashboard/internal/app"
+	"html"
+)
+
+// ownerToHtml lists the owner fields of an application, linking URLs like a docs-url. Labels and annotations are set
+// by whoever deploys the application, so everything is escaped.
+func ownerToHtml(owner []app.OwnerField) string {
+	result := `<ul class="owner">`
+	for _, field := range owner {
+		value := html.EscapeString(field.Value)
+		if field.IsLink() {
+			value = `<a href="` + value + `" target="_blank">` + value + `</a>`
+		}
+		result += `<li>` + html.EscapeString(field.Key) + `: ` + value + `</li>`
+	}
+	return result + `</ul>`
+}
@@ -0,0 +1,42 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package web
+
+import (
+	"dashboard/internal/app"
+	"testing"
+)
+
+func TestShouldListOwnerLinkingUrls(t *testing.T) {
+	owner := []app.OwnerField{
+		{Key: "team", Value: "<connector>"},
+		{Key: "docs-url", Value: "https://docs.example.org/edc?a=1&b=2"},
+		{Key: "contact", Value: "javascript:alert(1)"},
+	}
+
+	renderedHtml := ownerToHtml(owner)
+
+	expected := `<ul class="owner"><li>team: &lt;connector&gt;</li>` +
+		`<li>docs-url: <a href="https://docs.example.org/edc?a=1&amp;b=2" target="_blank">https://docs.example.org/edc?a=1&amp;b=2</a></li>` +
+		`<li>contact: javascript:alert(1)</li></ul>`
+	if renderedHtml != expected {
+		t.Errorf("Owner not rendered correctly! \nexpected: %s \nGot: %s", expected, renderedHtml)
+	}
+}
@@ -0,0 +1,65 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package web
+
+import (
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+
+	"dashboard/internal/app"
+)
+
+// podStatusToHtml summarizes the pods of an application, i.e. "2/3 ready, 8 restarts, 1 CrashLoopBackOff".
+func podStatusToHtml(summary *app.PodSummary) string {
+	if summary == nil {
+		return ""
+	}
+
+	ready := fmt.Sprintf("%d/%d ready", summary.Ready, summary.Total)
+	if summary.Ready < summary.Total {
+		ready = `<span class="attention">` + ready + `</span>`
+	}
+	result := []string{ready, fmt.Sprintf("%d restarts", summary.Restarts)}
+
+	reasons := map[string]int{}
+	for _, container := range summary.Problems() {
+		reasons[container.Reason]++
+	}
+	for _, reason := range sortedKeys(reasons) {
+		result = append(result, fmt.Sprintf(`<span class="attention">%d %s</span>`, reasons[reason], html.EscapeString(reason)))
+	}
+
+	if unlisted := summary.UnlistedImages(); len(unlisted) > 0 {
+		result = append(result, `<span class="attention" title="`+html.EscapeString(strings.Join(unlisted, "\n"))+
+			`">`+fmt.Sprintf("%d images not reported by Argo CD", len(unlisted))+`</span>`)
+	}
+	return strings.Join(result, ", ")
+}
+
+func sortedKeys(values map[string]int) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,49 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package web
+
+import (
+	"dashboard/internal/app"
+	"testing"
+)
+
+func TestShouldSummarizePods(t *testing.T) {
+	summary := &app.PodSummary{Total: 3, Ready: 2, Restarts: 8, Containers: []app.Container{
+		{Pod: "controlplane-1", Image: "tractusx/edc-controlplane:0.6.0", State: "Running", Listed: true},
+		{Pod: "dataplane-1", Image: "tractusx/edc-dataplane:0.6.1", State: "Waiting", Reason: "CrashLoopBackOff"},
+		{Pod: "dataplane-2", Image: "tractusx/edc-dataplane:0.6.1", State: "Waiting", Reason: "CrashLoopBackOff"},
+	}}
+
+	renderedHtml := podStatusToHtml(summary)
+
+	expected := `<span class="attention">2/3 ready</span>, 8 restarts, <span class="attention">2 CrashLoopBackOff</span>, ` +
+		`<span class="attention" title="tractusx/edc-dataplane:0.6.1">1 images not reported by Argo CD</span>`
+	if renderedHtml != expected {
+		t.Errorf("Pods not summarized correctly! \nexpected: %s \nGot: %s", expected, renderedHtml)
+	}
+}
+
+func TestShouldSummarizeHealthyPodsWithoutHighlighting(t *testing.T) {
+	summary := &app.PodSummary{Total: 1, Ready: 1, Containers: []app.Container{{Pod: "irs-1", State: "Running", Listed: true}}}
+
+	if renderedHtml := podStatusToHtml(summary); renderedHtml != "1/1 ready, 0 restarts" {
+		t.Errorf("Healthy pods not summarized correctly! \nexpected: %s \nGot: %s", "1/1 ready, 0 restarts", renderedHtml)
+	}
+}
@@ -0,0 +1,95 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package web
+
+import (
+	"dashboard/internal/app"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+)
+
+// certificateWarning is how long before the expiry of a certificate it is pointed out.
+const certificateWarning = 30 * 24 * time.Hour
+
+// probeStatusToHtml shows whether the URL was reachable at the last probe, with the details on hover.
+func probeStatusToHtml(probe *app.Probe) string {
+	var details string
+	if probe.Error != "" {
+		details = probe.Error
+	} else {
+		details = fmt.Sprintf("%d in %v", probe.StatusCode, probe.Latency.Round(time.Millisecond))
+	}
+	details += ", checked " + probe.CheckedAt.Format("2006-01-02 15:04:05")
+
+	class := "probe-failed"
+	if probe.IsReachable() {
+		class = "probe-ok"
+	}
+	result := `<span class="probe ` + class + `" title="` + html.EscapeString(details) + `">&#9679;</span>`
+	if warning := certificateWarningOf(*probe); warning != "" {
+		result += ` <span class="attention">` + warning + `</span>`
+	}
+	return result
+}
+
+func certificateWarningOf(probe app.Probe) string {
+	if probe.CertificateExpiry == nil {
+		return ""
+	}
+	remaining := probe.CertificateExpiry.Sub(currentTime())
+	switch {
+	case remaining <= 0:
+		return "certificate expired"
+	case remaining < certificateWarning:
+		return fmt.Sprintf("certificate expires in %d days", int(remaining.Hours()/24))
+	}
+	return ""
+}
+
+// probeSummaryToHtml points out unreachable URLs and expiring certificates of the application.
+func probeSummaryToHtml(item app.Item) string {
+	unreachable, expiring := 0, 0
+	for _, url := range item.Status.Summary.ExternalUrls {
+		probe := item.ProbeOf(url)
+		if probe == nil {
+			continue
+		}
+		if !probe.IsReachable() {
+			unreachable++
+		}
+		if certificateWarningOf(*probe) != "" {
+			expiring++
+		}
+	}
+
+	var problems []string
+	if unreachable > 0 {
+		problems = append(problems, fmt.Sprintf("%d unreachable", unreachable))
+	}
+	if expiring > 0 {
+		problems = append(problems, fmt.Sprintf("%d certificates expiring", expiring))
+	}
+	if len(problems) == 0 {
+		return ""
+	}
+	return `<span class="attention">` + strings.Join(problems, ", ") + `</span>`
+}
@@ -0,0 +1,95 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package web
+
+import (
+	"dashboard/internal/app"
+	"testing"
+	"time"
+)
+
+func givenTime(t *testing.T, current time.Time) {
+	currentTime = func() time.Time { return current }
+	t.Cleanup(func() { currentTime = getCurrentTime })
+}
+
+func TestShouldShowReachableUrlsWithExpiringCertificates(t *testing.T) {
+	current := time.Date(2023, 10, 20, 8, 0, 0, 0, time.UTC)
+	givenTime(t, current)
+	expiry := current.Add(10*24*time.Hour + time.Hour)
+	probe := &app.Probe{StatusCode: 200, Latency: 123456 * time.Microsecond, CertificateExpiry: &expiry, CheckedAt: current}
+
+	renderedHtml := probeStatusToHtml(probe)
+
+	expected := `<span class="probe probe-ok" title="200 in 123ms, checked 2023-10-20 08:00:00">&#9679;</span> ` +
+		`<span class="attention">certificate expires in 10 days</span>`
+	if renderedHtml != expected {
+		t.Errorf("Probe not rendered correctly! \nexpected: %s \nGot: %s", expected, renderedHtml)
+	}
+}
+
+func TestShouldShowUnreachableUrlsWithTheError(t *testing.T) {
+	current := time.Date(2023, 10, 20, 8, 0, 0, 0, time.UTC)
+	givenTime(t, current)
+	probe := &app.Probe{Error: `Get "https://edc.example.org": dial tcp: lookup edc.example.org: no such host`, CheckedAt: current}
+
+	renderedHtml := probeStatusToHtml(probe)
+
+	expected := `<span class="probe probe-failed" title="Get &#34;https://edc.example.org&#34;: dial tcp: lookup edc.example.org: ` +
+		`no such host, checked 2023-10-20 08:00:00">&#9679;</span>`
+	if renderedHtml != expected {
+		t.Errorf("Probe not rendered correctly! \nexpected: %s \nGot: %s", expected, renderedHtml)
+	}
+}
+
+func TestShouldPointOutExpiredCertificatesOfUnreachableUrls(t *testing.T) {
+	current := time.Date(2023, 10, 20, 8, 0, 0, 0, time.UTC)
+	givenTime(t, current)
+	expired := current.Add(-24 * time.Hour)
+	probe := &app.Probe{Error: "x509: certificate has expired or is not yet valid", CheckedAt: current, CertificateExpiry: &expired}
+
+	renderedHtml := probeStatusToHtml(probe)
+
+	expected := `<span class="probe probe-failed" title="x509: certificate has expired or is not yet valid, checked 2023-10-20 08:00:00">` +
+		`&#9679;</span> <span class="attention">certificate expired</span>`
+	if renderedHtml != expected {
+		t.Errorf("Expired certificate not pointed out! \nexpected: %s \nGot: %s", expected, renderedHtml)
+	}
+}
+
+func TestShouldSummarizeUnreachableUrlsAndExpiringCertificates(t *testing.T) {
+	current := time.Date(2023, 10, 20, 8, 0, 0, 0, time.UTC)
+	givenTime(t, current)
+	expired := current.Add(-time.Hour)
+	valid := current.Add(90 * 24 * time.Hour)
+	item := app.Item{Probes: map[string]app.Probe{
+		"https://edc.example.org":    {StatusCode: 200, CertificateExpiry: &expired},
+		"https://portal.example.org": {StatusCode: 503, CertificateExpiry: &valid},
+		"https://unused.example.org": {StatusCode: 503},
+	}}
+	item.Status.Summary.ExternalUrls = []string{"https://edc.example.org", "https://portal.example.org", "https://bpdm.example.org"}
+
+	renderedHtml := probeSummaryToHtml(item)
+
+	expected := `<span class="attention">1 unreachable, 1 certificates expiring</span>`
+	if renderedHtml != expected {
+		t.Errorf("Probes not summarized correctly! \nexpected: %s \nGot: %s", expected, renderedHtml)
+	}
+}
@@ -0,0 +1,64 @@
+/*******************************************************************************
+ * Copyright (c) 2021,2023 Contributors to the Eclipse Foundation
+ *
+ * See the NOTICE file(s) distributed with this work for additional
+ * information regarding copyright ownership.
+ *
+ * This program and the accompanying materials are made available under the
+ * terms of the Apache License, Version 2.0 which is available at
+ * https://www.apache.org/licenses/LICENSE-2.0.
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+ * WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the
+ * License for the specific language governing permissions and limitations
+ * under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ ******************************************************************************/
+
+package web
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"dashboard/internal/release"
+)
+
+var releaseStatusColors = map[release.Status]string{
+	release.Match:    colorGreen,
+	release.Ahead:    colorBlue,
+	release.Behind:   colorYellow,
+	release.Mismatch: colorRed,
+	release.Missing:  colorRed,
+}
+
+// releaseStatusToHtml renders the conformance of an application with the release, nothing if it is no part of it.
+func releaseStatusToHtml(result *release.Result) string {
+	if result == nil {
+		return ""
+	}
+
+	title := "Expected " + result.Expected
+	if len(result.Details) > 0 {
+		title = strings.Join(result.Details, "; ")
+	}
+	return `<span class="release-status" title="` + html.EscapeString(title) + `" style="color: ` +
+		releaseStatusColors[result.Status] + `;">` + string(result.Status) + `</span>`
+}
+
+// releaseSummaryToHtml counts the results by status, i.e. "3 match, 1 behind".
+func releaseSummaryToHtml(report release.Report) string {
+	var counts []string
+	for _, status := range release.Statuses {
+		if count := report.Count(status); count > 0 {
+			counts = append(counts, fmt.Sprintf(`<span style="color: %s;">%d %s</span>`, releaseStatusColors[status], count, status))
+		}
+	}
+	if len(counts) == 0 {
+		return "no products"
+	}
+	return strings.Join(counts, ", ")
+}
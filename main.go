@@ -21,42 +21,10 @@
 package main
 
 import (
-	"dashboard/internal/app"
-	"dashboard/internal/gateway"
-	"dashboard/internal/web"
+	"dashboard/internal/cli"
 	"os"
-	"strings"
-	"time"
 )
 
 func main() {
-	dashboard := app.NewDashboard(gateway.NewApplicationGateway(), web.NewWebserver(), getAppConfig())
-	dashboard.Run()
-
-	time.Sleep(time.Duration(1<<63 - 1))
-}
-
-func getAppConfig() *app.ApplicationConfig {
-	return &app.ApplicationConfig{
-		IgnoredNamespaces: getIgnoredNamespaces(),
-		EnvironmentName:   getEnvironmentName(),
-	}
-}
-
-func getEnvironmentName() string {
-	envNameFromENV := strings.TrimSpace(os.Getenv("ENVIRONMENT_NAME"))
-
-	if envNameFromENV != "" {
-		return envNameFromENV
-	}
-	return "Unset"
-}
-
-func getIgnoredNamespaces() []string {
-	ignoreNamespaceRaw := strings.TrimSpace(os.Getenv("IGNORE_NAMESPACE"))
-
-	if ignoreNamespaceRaw != "" {
-		return strings.Split(ignoreNamespaceRaw, ",")
-	}
-	return []string{}
+	os.Exit(cli.Run(os.Args[1:]))
 }
@@ -26,4 +26,17 @@ window.onload = afterLoad()
 
 const dataTablee = new simpleDatatables.DataTable("#main", {
     paging: false
+})
+
+// pass the current search of the table on to the exports
+document.querySelectorAll("a.export-link").forEach(link => {
+    link.addEventListener("click", () => {
+        const search = document.querySelector(".dataTable-input")
+        const url = new URL(link.href)
+        url.searchParams.delete("q")
+        if (search && search.value.trim() !== "") {
+            url.searchParams.set("q", search.value.trim())
+        }
+        link.href = url.toString()
+    })
 })
\ No newline at end of file